@@ -0,0 +1,271 @@
+package n26api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/attachment"
+)
+
+// DefaultAttachmentMaxSize is the default size limit of an uploaded attachment.
+const DefaultAttachmentMaxSize int64 = 10 << 20
+
+// sniffLen is the number of bytes http.DetectContentType considers.
+const sniffLen = 512
+
+var (
+	// ErrAttachmentNotFound indicates that the attachment does not exist.
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	// ErrAttachmentTooLarge indicates that the attachment exceeds the size limit.
+	ErrAttachmentTooLarge = errors.New("attachment is too large")
+	// ErrAttachmentContentTypeNotSupported indicates that the attachment is neither an image nor a PDF document.
+	ErrAttachmentContentTypeNotSupported = errors.New("attachment content type is not supported")
+)
+
+var _ attachment.Service = (*Client)(nil)
+
+var supportedAttachmentContentTypes = map[string]struct{}{
+	"application/pdf": {},
+	"image/jpeg":      {},
+	"image/png":       {},
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// WithAttachmentMaxSize sets size limit (in bytes) of an uploaded attachment, 0 or less means no limit.
+func WithAttachmentMaxSize(size int64) Option {
+	return func(c *Client) {
+		c.config.attachmentMaxSize = size
+	}
+}
+
+func attachmentsURI(baseURL string, transactionID uuid.UUID) string {
+	return fmt.Sprintf("%s/api/smrt/transactions/%s/attachments", baseURL, transactionID.String())
+}
+
+// FindAllAttachments finds all attachments of a transaction.
+func (c *Client) FindAllAttachments(ctx context.Context, transactionID uuid.UUID) ([]attachment.Attachment, error) {
+	res, err := c.api.GetAPISmrtTransactionsIDAttachments(ctx, api.GetAPISmrtTransactionsIDAttachmentsRequest{
+		ID: transactionID.String(),
+	})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not find attachments", "transaction_id", transactionID)
+	}
+
+	if res.ValueNotFound != nil {
+		return nil, ctxd.WrapError(ctx, ErrTransactionNotFound, "could not find attachments", "transaction_id", transactionID)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not find attachments: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not find attachments: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
+
+// UploadAttachment uploads an image or a PDF document read from r to a transaction.
+//
+// The content is streamed to N26, the content type is detected from its first bytes and only JPEG, PNG and PDF are
+// accepted. The upload is aborted with ErrAttachmentTooLarge once the size limit is exceeded, see
+// WithAttachmentMaxSize.
+func (c *Client) UploadAttachment(
+	ctx context.Context,
+	transactionID uuid.UUID,
+	fileName string,
+	r io.Reader,
+) (*attachment.Attachment, error) {
+	br := bufio.NewReaderSize(r, sniffLen)
+
+	head, err := br.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, ctxd.WrapError(ctx, err, "could not upload attachment", "transaction_id", transactionID)
+	}
+
+	contentType := http.DetectContentType(head)
+
+	if _, ok := supportedAttachmentContentTypes[contentType]; !ok {
+		return nil, ctxd.WrapError(ctx, ErrAttachmentContentTypeNotSupported, "could not upload attachment",
+			"transaction_id", transactionID,
+			"content_type", contentType,
+		)
+	}
+
+	var content io.Reader = br
+
+	if c.config.attachmentMaxSize > 0 {
+		content = &limitedReader{r: br, n: c.config.attachmentMaxSize}
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	done := make(chan error, 1)
+
+	go func() {
+		err := writeAttachment(mw, fileName, contentType, content)
+
+		_ = pw.CloseWithError(err)
+
+		done <- err
+	}()
+
+	ctx = instrumentCtx(ctx, http.MethodPost, "/api/smrt/transactions/{id}/attachments", nil)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, attachmentsURI(c.config.baseURL, transactionID), pr)
+	if err != nil {
+		_ = pr.Close()
+		<-done
+
+		return nil, ctxd.WrapError(ctx, err, "could not upload attachment", "transaction_id", transactionID)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+
+	res, err := c.http.Do(req)
+
+	// Unblock the writer if the request ends before the whole content is sent.
+	_ = pr.Close()
+
+	if writeErr := <-done; errors.Is(writeErr, ErrAttachmentTooLarge) {
+		if err == nil {
+			_ = res.Body.Close()
+		}
+
+		return nil, ctxd.WrapError(ctx, ErrAttachmentTooLarge, "could not upload attachment",
+			"transaction_id", transactionID,
+			"max_size", c.config.attachmentMaxSize,
+		)
+	}
+
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not upload attachment", "transaction_id", transactionID)
+	}
+
+	defer res.Body.Close() // nolint:errcheck
+
+	switch res.StatusCode {
+	case http.StatusCreated, http.StatusOK:
+		var result attachment.Attachment
+
+		if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
+			return nil, ctxd.WrapError(ctx, err, "could not upload attachment: could not decode response",
+				"transaction_id", transactionID,
+			)
+		}
+
+		return &result, nil
+
+	case http.StatusBadRequest:
+		fields := []interface{}{"transaction_id", transactionID}
+
+		var result api.BadRequestError
+
+		if err := json.NewDecoder(res.Body).Decode(&result); err == nil {
+			fields = append(fields, "reason", result.UserMessage.Detail)
+		}
+
+		return nil, ctxd.NewError(ctx, "could not upload attachment: invalid attachment", fields...)
+
+	case http.StatusUnauthorized:
+		return nil, ctxd.NewError(ctx, "could not upload attachment: invalid token", "transaction_id", transactionID)
+
+	case http.StatusNotFound:
+		return nil, ctxd.WrapError(ctx, ErrTransactionNotFound, "could not upload attachment", "transaction_id", transactionID)
+
+	case http.StatusRequestEntityTooLarge:
+		return nil, ctxd.WrapError(ctx, ErrAttachmentTooLarge, "could not upload attachment", "transaction_id", transactionID)
+	}
+
+	return nil, ctxd.WrapError(ctx, errors.New("unexpected response status: "+res.Status), "could not upload attachment", // nolint:goerr113
+		"transaction_id", transactionID,
+	)
+}
+
+// DownloadAttachment downloads an attachment of a transaction and writes its content to w.
+func (c *Client) DownloadAttachment(ctx context.Context, transactionID uuid.UUID, attachmentID uuid.UUID, w io.Writer) error {
+	requestURI := fmt.Sprintf("%s/%s", attachmentsURI(c.config.baseURL, transactionID), attachmentID.String())
+
+	ctx = instrumentCtx(ctx, http.MethodGet, "/api/smrt/transactions/{id}/attachments/{attachmentId}", nil)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURI, nil)
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not download attachment", "transaction_id", transactionID, "id", attachmentID)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not download attachment", "transaction_id", transactionID, "id", attachmentID)
+	}
+
+	defer res.Body.Close() // nolint:errcheck
+
+	switch res.StatusCode {
+	case http.StatusOK:
+		if _, err := io.Copy(w, res.Body); err != nil {
+			return ctxd.WrapError(ctx, err, "could not download attachment", "transaction_id", transactionID, "id", attachmentID)
+		}
+
+		return nil
+
+	case http.StatusUnauthorized:
+		return ctxd.NewError(ctx, "could not download attachment: invalid token", "transaction_id", transactionID, "id", attachmentID)
+
+	case http.StatusNotFound:
+		return ctxd.WrapError(ctx, ErrAttachmentNotFound, "could not download attachment", "transaction_id", transactionID, "id", attachmentID)
+	}
+
+	return ctxd.WrapError(ctx, errors.New("unexpected response status: "+res.Status), "could not download attachment", // nolint:goerr113
+		"transaction_id", transactionID,
+		"id", attachmentID,
+	)
+}
+
+func writeAttachment(mw *multipart.Writer, fileName, contentType string, r io.Reader) error {
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(fileName)))
+	h.Set("Content-Type", contentType)
+
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(part, r); err != nil {
+		return err
+	}
+
+	return mw.Close()
+}
+
+// limitedReader reads from r and fails with ErrAttachmentTooLarge once more than n bytes are read.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+
+	if l.n < 0 {
+		return n, ErrAttachmentTooLarge
+	}
+
+	return n, err
+}
@@ -0,0 +1,306 @@
+package n26api_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/attachment"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+var pdfContent = []byte("%PDF-1.4\n%receipt\n")
+
+func TestClient_FindAllAttachments(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	transactionID := uuid.New()
+	attachments := []attachment.Attachment{
+		{ID: uuid.New(), TransactionID: transactionID, FileName: "receipt.pdf", ContentType: "application/pdf", Size: 42},
+	}
+
+	testCases := []struct {
+		scenario            string
+		mockServer          testkit.ServerMocker
+		expectedAttachments []attachment.Attachment
+		expectedError       string
+	}{
+		{
+			scenario:      "transaction not found",
+			mockServer:    mockServer(deviceID, testkit.WithFindAllAttachmentsFailureNotFound(transactionID)),
+			expectedError: "could not find attachments: transaction not found",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/smrt/transactions/" + transactionID.String() + "/attachments").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not find attachments: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:            "success",
+			mockServer:          mockServer(deviceID, testkit.WithFindAllAttachments(transactionID, attachments)),
+			expectedAttachments: attachments,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.FindAllAttachments(context.Background(), transactionID)
+
+			assert.Equal(t, tc.expectedAttachments, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_UploadAttachment(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	transactionID := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockServer    testkit.ServerMocker
+		options       []n26api.Option
+		fileName      string
+		content       []byte
+		expectedSize  int64
+		expectedError error
+		expectedMsg   string
+	}{
+		{
+			scenario:      "unsupported content type",
+			mockServer:    testkit.MockEmptyServer(),
+			fileName:      "receipt.txt",
+			content:       []byte("just some text"),
+			expectedError: n26api.ErrAttachmentContentTypeNotSupported,
+			expectedMsg:   "could not upload attachment: attachment content type is not supported",
+		},
+		{
+			scenario:      "too large",
+			mockServer:    mockServer(deviceID),
+			options:       []n26api.Option{n26api.WithAttachmentMaxSize(1024)},
+			fileName:      "receipt.pdf",
+			content:       append(append([]byte{}, pdfContent...), bytes.Repeat([]byte{'x'}, 2048)...),
+			expectedError: n26api.ErrAttachmentTooLarge,
+			expectedMsg:   "could not upload attachment: attachment is too large",
+		},
+		{
+			scenario:      "too large on server side",
+			mockServer:    mockServer(deviceID, testkit.WithUploadAttachmentFailureTooLarge(transactionID, "receipt.pdf", "application/pdf", pdfContent)),
+			fileName:      "receipt.pdf",
+			content:       pdfContent,
+			expectedError: n26api.ErrAttachmentTooLarge,
+			expectedMsg:   "could not upload attachment: attachment is too large",
+		},
+		{
+			scenario: "transaction not found",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPost("/api/smrt/transactions/" + transactionID.String() + "/attachments").
+					ReturnCode(http.StatusNotFound)
+			}),
+			fileName:      "receipt.pdf",
+			content:       pdfContent,
+			expectedError: n26api.ErrTransactionNotFound,
+			expectedMsg:   "could not upload attachment: transaction not found",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPost("/api/smrt/transactions/" + transactionID.String() + "/attachments").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			fileName:    "receipt.pdf",
+			content:     pdfContent,
+			expectedMsg: "could not upload attachment: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:     "success",
+			mockServer:   mockServer(deviceID, testkit.WithUploadAttachmentSuccess(transactionID, "receipt.pdf", "application/pdf", pdfContent)),
+			fileName:     "receipt.pdf",
+			content:      pdfContent,
+			expectedSize: int64(len(pdfContent)),
+		},
+		{
+			scenario:     "no size limit",
+			mockServer:   mockServer(deviceID, testkit.WithUploadAttachmentSuccess(transactionID, "receipt.pdf", "application/pdf", pdfContent)),
+			options:      []n26api.Option{n26api.WithAttachmentMaxSize(0)},
+			fileName:     "receipt.pdf",
+			content:      pdfContent,
+			expectedSize: int64(len(pdfContent)),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID, tc.options...)
+
+			result, err := c.UploadAttachment(context.Background(), transactionID, tc.fileName, bytes.NewReader(tc.content))
+
+			if tc.expectedMsg == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, transactionID, result.TransactionID)
+				assert.Equal(t, tc.fileName, result.FileName)
+				assert.Equal(t, "application/pdf", result.ContentType)
+				assert.Equal(t, tc.expectedSize, result.Size)
+			} else {
+				assert.Nil(t, result)
+				assert.EqualError(t, err, tc.expectedMsg)
+
+				if tc.expectedError != nil {
+					assert.True(t, errors.Is(err, tc.expectedError))
+				}
+			}
+		})
+	}
+}
+
+func TestClient_DownloadAttachment(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	transactionID := uuid.New()
+	attachmentID := uuid.New()
+
+	testCases := []struct {
+		scenario        string
+		mockServer      testkit.ServerMocker
+		expectedContent []byte
+		expectedError   string
+	}{
+		{
+			scenario:      "attachment not found",
+			mockServer:    mockServer(deviceID, testkit.WithDownloadAttachmentFailureNotFound(transactionID, attachmentID)),
+			expectedError: "could not download attachment: attachment not found",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/smrt/transactions/" + transactionID.String() + "/attachments/" + attachmentID.String()).
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not download attachment: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:        "success",
+			mockServer:      mockServer(deviceID, testkit.WithDownloadAttachment(transactionID, attachmentID, "application/pdf", pdfContent)),
+			expectedContent: pdfContent,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			buf := new(bytes.Buffer)
+			err := c.DownloadAttachment(context.Background(), transactionID, attachmentID, buf)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedContent, buf.Bytes())
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_UploadAttachment_BadRequest(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	transactionID := uuid.New()
+
+	testCases := []struct {
+		scenario       string
+		body           string
+		expectedFields map[string]interface{}
+	}{
+		{
+			scenario: "with reason",
+			body:     `{"status":400,"userMessage":{"title":"Oops!","detail":"The file is corrupted"}}`,
+			expectedFields: map[string]interface{}{
+				"transaction_id": transactionID,
+				"reason":         "The file is corrupted",
+			},
+		},
+		{
+			scenario: "without reason",
+			body:     `bad request`,
+			expectedFields: map[string]interface{}{
+				"transaction_id": transactionID,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPost("/api/smrt/transactions/" + transactionID.String() + "/attachments").
+					ReturnCode(http.StatusBadRequest).
+					Return(tc.body)
+			})(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.UploadAttachment(context.Background(), transactionID, "receipt.pdf", bytes.NewReader(pdfContent))
+
+			assert.Nil(t, result)
+			assert.EqualError(t, err, "could not upload attachment: invalid attachment")
+
+			var se ctxd.StructuredError
+
+			require.True(t, errors.As(err, &se))
+			assert.Equal(t, tc.expectedFields, se.Fields())
+		})
+	}
+}
+
+func TestClient_UploadAttachment_ReadError(t *testing.T) {
+	t.Parallel()
+
+	c := n26api.NewClient(n26api.WithBaseURL("http://localhost"))
+
+	_, err := c.UploadAttachment(context.Background(), uuid.New(), "receipt.pdf", errorReader{})
+
+	assert.EqualError(t, err, "could not upload attachment: read error")
+}
+
+type errorReader struct{}
+
+func (errorReader) Read([]byte) (int, error) {
+	return 0, errors.New("read error")
+}
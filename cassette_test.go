@@ -0,0 +1,266 @@
+package n26api_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/cassette"
+	"github.com/nhatthm/n26api/pkg/contact"
+	"github.com/nhatthm/n26api/pkg/device"
+	"github.com/nhatthm/n26api/pkg/directdebit"
+	"github.com/nhatthm/n26api/pkg/testkit"
+	"github.com/nhatthm/n26api/pkg/transaction"
+	"github.com/nhatthm/n26api/pkg/user"
+)
+
+func TestCassette_RecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	path := filepath.Join(t.TempDir(), "devices.json")
+	devices := []device.Device{{DeviceToken: deviceID, Name: "n26api"}}
+
+	// The personal data is scrubbed from the cassette.
+	scrubbed := []device.Device{{DeviceToken: uuid.MustParse(cassette.RedactedUUID), Name: cassette.Redacted}}
+
+	// Record a session.
+	s := mockServer(deviceID, testkit.WithFindAllDevices(devices))(t)
+	result, err := newTestClient(s, deviceID, n26api.WithTransport(cassette.NewRecorder(path))).FindAllDevices(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, devices, result)
+
+	data, err := os.ReadFile(path) // nolint: gosec
+	require.NoError(t, err)
+
+	for _, secret := range []string{
+		n26Password,
+		s.MFAToken().String(),
+		string(s.AccessToken()),
+		string(s.RefreshToken()),
+		s.BasicAuthorization(),
+		deviceID.String(),
+	} {
+		assert.NotContains(t, string(data), secret)
+	}
+
+	c, err := cassette.Load(path)
+	require.NoError(t, err)
+
+	t.Run("replayer", func(t *testing.T) {
+		t.Parallel()
+
+		r := cassette.NewReplayer(c)
+
+		result, err := newTestClient(s, deviceID,
+			n26api.WithBaseURL("https://n26.invalid"),
+			n26api.WithTransport(r),
+		).FindAllDevices(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, scrubbed, result)
+		assert.Equal(t, 0, r.Remaining())
+	})
+
+	t.Run("testkit", func(t *testing.T) {
+		t.Parallel()
+
+		s := testkit.MockCassetteServer(path)(t)
+
+		result, err := newTestClient(s, deviceID).FindAllDevices(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, scrubbed, result)
+	})
+}
+
+func TestCassette_ScrubFixtures(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	otherDevice := uuid.New()
+	contactID := uuid.New()
+	accountID := uuid.New()
+	cardID := uuid.New()
+	from := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	to := from.Add(time.Hour)
+
+	account := contact.Account{AccountType: "sepa", Iban: "DE89370400440532013000", Bic: "COBADEFFXXX"}
+	tx := transaction.Transaction{
+		ID:            uuid.New(),
+		AccountID:     accountID,
+		CardID:        cardID,
+		MerchantName:  "Corner Bakery",
+		PartnerName:   "Jane Roe",
+		PartnerIban:   "DE02120300000000202051",
+		ReferenceText: "Rent for flat 4B",
+	}
+	profile := user.Profile{Info: user.Info{
+		Email:             "john.doe@example.com",
+		FirstName:         "Johnny",
+		LastName:          "Doeson",
+		Gender:            "MALE",
+		Nationality:       "FRA",
+		MobilePhoneNumber: "+4915112345678",
+	}}
+
+	testCases := []struct {
+		scenario string
+		mocks    []testkit.ServerOption
+		call     func(c *n26api.Client) error
+		secrets  []string
+	}{
+		{
+			scenario: "devices",
+			mocks: []testkit.ServerOption{
+				testkit.WithFindAllDevices([]device.Device{{DeviceToken: otherDevice, Name: "Johnny's phone"}}),
+			},
+			call: func(c *n26api.Client) error {
+				_, err := c.FindAllDevices(context.Background())
+
+				return err
+			},
+			secrets: []string{otherDevice.String(), "Johnny"},
+		},
+		{
+			scenario: "unpair device",
+			mocks:    []testkit.ServerOption{testkit.WithUnpairDeviceSuccess(otherDevice)},
+			call: func(c *n26api.Client) error {
+				return c.UnpairDevice(context.Background(), otherDevice)
+			},
+			secrets: []string{otherDevice.String()},
+		},
+		{
+			scenario: "contacts",
+			mocks: []testkit.ServerOption{
+				testkit.WithFindAllContacts([]contact.Contact{{ID: contactID, Name: "Jane Roe", Account: account}}),
+			},
+			call: func(c *n26api.Client) error {
+				_, err := c.FindAllContacts(context.Background())
+
+				return err
+			},
+			secrets: []string{"Jane Roe", account.Iban, account.Bic},
+		},
+		{
+			scenario: "create contact",
+			mocks:    []testkit.ServerOption{testkit.WithCreateContactSuccess("Jane Roe", account)},
+			call: func(c *n26api.Client) error {
+				_, err := c.CreateContact(context.Background(), "Jane Roe", account)
+
+				return err
+			},
+			secrets: []string{"Jane Roe", account.Iban, account.Bic},
+		},
+		{
+			scenario: "delete contact",
+			mocks:    []testkit.ServerOption{testkit.WithDeleteContactSuccess(contactID)},
+			call: func(c *n26api.Client) error {
+				return c.DeleteContact(context.Background(), contactID)
+			},
+			secrets: []string{contactID.String()},
+		},
+		{
+			scenario: "mandates",
+			mocks: []testkit.ServerOption{
+				testkit.WithFindAllMandates([]directdebit.Mandate{{
+					ID:               uuid.New(),
+					CreditorName:     "Landlord Ltd",
+					MandateReference: "MANDATE-REF-42",
+				}}),
+			},
+			call: func(c *n26api.Client) error {
+				_, err := c.FindAllMandates(context.Background())
+
+				return err
+			},
+			secrets: []string{"Landlord Ltd", "MANDATE-REF-42"},
+		},
+		{
+			scenario: "transactions",
+			mocks: []testkit.ServerOption{
+				testkit.WithFindAllTransactionsInRange(from, to, n26api.DefaultPageSize, []transaction.Transaction{tx}),
+			},
+			call: func(c *n26api.Client) error {
+				_, err := c.FindAllTransactionsInRange(context.Background(), from, to)
+
+				return err
+			},
+			secrets: []string{accountID.String(), cardID.String(), tx.MerchantName, tx.PartnerName, tx.PartnerIban, tx.ReferenceText},
+		},
+		{
+			scenario: "transaction",
+			mocks:    []testkit.ServerOption{testkit.WithGetTransaction(tx)},
+			call: func(c *n26api.Client) error {
+				_, err := c.GetTransaction(context.Background(), tx.ID)
+
+				return err
+			},
+			secrets: []string{tx.ID.String(), accountID.String(), cardID.String(), tx.MerchantName, tx.PartnerName},
+		},
+		{
+			scenario: "me",
+			mocks:    []testkit.ServerOption{testkit.WithMe(profile)},
+			call: func(c *n26api.Client) error {
+				_, err := c.Me(context.Background())
+
+				return err
+			},
+			secrets: []string{
+				profile.Info.Email,
+				profile.Info.FirstName,
+				profile.Info.LastName,
+				profile.Info.Gender,
+				profile.Info.Nationality,
+				profile.Info.MobilePhoneNumber,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "cassette.json")
+			s := mockServer(deviceID, tc.mocks...)(t)
+
+			err := tc.call(newTestClient(s, deviceID, n26api.WithTransport(cassette.NewRecorder(path))))
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(path) // nolint: gosec
+			require.NoError(t, err)
+
+			for _, secret := range append(tc.secrets,
+				n26Username,
+				n26Password,
+				deviceID.String(),
+				s.UserID().String(),
+				s.MFAToken().String(),
+				string(s.AccessToken()),
+				string(s.RefreshToken()),
+			) {
+				assert.NotContains(t, string(data), secret)
+			}
+
+			// The scrubbed cassette can still be replayed.
+			c, err := cassette.Load(path)
+			require.NoError(t, err)
+
+			r := cassette.NewReplayer(c)
+
+			err = tc.call(newTestClient(s, deviceID, n26api.WithTransport(r)))
+			require.NoError(t, err)
+
+			assert.Equal(t, 0, r.Remaining())
+		})
+	}
+}
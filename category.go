@@ -0,0 +1,44 @@
+package n26api
+
+import (
+	"context"
+
+	"github.com/bool64/ctxd"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/category"
+	"github.com/nhatthm/n26api/pkg/util"
+)
+
+var _ category.Finder = (*Client)(nil)
+
+// WithCategoriesLanguage sets the language of the category names, e.g. en or de.
+func WithCategoriesLanguage(language string) Option {
+	return func(c *Client) {
+		c.config.categoriesLanguage = language
+	}
+}
+
+// FindAllCategories finds all categories.
+func (c *Client) FindAllCategories(ctx context.Context) ([]category.Category, error) {
+	req := api.GetAPISmrtCategoriesRequest{}
+
+	if c.config.categoriesLanguage != "" {
+		req.AcceptLanguage = util.StringPtr(c.config.categoriesLanguage)
+	}
+
+	res, err := c.api.GetAPISmrtCategories(ctx, req)
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not find categories")
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not find categories: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not find categories: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
@@ -0,0 +1,100 @@
+package n26api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/category"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestClient_FindAllCategories(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	categories := []category.Category{
+		{ID: "micro-v2-food-groceries", Name: "Food & Groceries"},
+		{ID: "micro-v2-income", Name: "Income"},
+	}
+
+	testCases := []struct {
+		scenario           string
+		mockServer         testkit.ServerMocker
+		language           string
+		expectedCategories []category.Category
+		expectedError      string
+	}{
+		{
+			scenario: "invalid token",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/smrt/categories").
+					ReturnCode(http.StatusUnauthorized).
+					ReturnJSON(api.InvalidTokenError{
+						Status: http.StatusUnauthorized,
+						Detail: "Invalid token",
+						Type:   "error",
+						UserMessage: api.UserMessage{
+							Title:  "Login attempt expired",
+							Detail: "That took too long, please try again.",
+						},
+						Error:            "invalid_token",
+						ErrorDescription: "Invalid token",
+					})
+			}),
+			expectedError: "could not find categories: invalid token",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/smrt/categories").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not find categories: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:           "success",
+			mockServer:         mockServer(deviceID, testkit.WithFindAllCategories(categories)),
+			expectedCategories: categories,
+		},
+		{
+			scenario:           "success with language",
+			mockServer:         mockServer(deviceID, testkit.WithFindAllCategoriesInLanguage("de", categories)),
+			language:           "de",
+			expectedCategories: categories,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := n26api.NewClient(
+				n26api.WithBaseURL(s.URL()),
+				n26api.WithDeviceID(deviceID),
+				n26api.WithCredentials(n26Username, n26Password),
+				n26api.WithMFAWait(5*time.Millisecond),
+				n26api.WithMFATimeout(time.Second),
+				n26api.WithCategoriesLanguage(tc.language),
+			)
+
+			result, err := c.FindAllCategories(context.Background())
+
+			assert.Equal(t, tc.expectedCategories, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
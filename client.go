@@ -1,14 +1,19 @@
 package n26api
 
 import (
+	"context"
 	"net/http"
 	"time"
 
+	"github.com/bool64/ctxd"
 	"github.com/google/uuid"
 	"go.nhat.io/clock"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/oauth2"
 
 	"github.com/nhatthm/n26api/internal/api"
 	"github.com/nhatthm/n26api/pkg/auth"
+	"github.com/nhatthm/n26api/pkg/metrics"
 )
 
 const (
@@ -27,9 +32,11 @@ type Option func(c *Client)
 
 // Client provides all N26 APIs.
 type Client struct {
-	api   *api.Client
-	token *chainTokenProvider
-	clock clock.Clock
+	api      *api.Client
+	http     *http.Client
+	apiToken *apiTokenProvider
+	token    *chainTokenProvider
+	clock    clock.Clock
 
 	config *config
 }
@@ -46,10 +53,17 @@ type config struct {
 	password string
 	deviceID uuid.UUID
 
-	mfaTimeout time.Duration
-	mfaWait    time.Duration
+	mfaTimeout  time.Duration
+	mfaWait     time.Duration
+	mfaProgress MFAProgressFunc
+
+	tracerProvider trace.TracerProvider
+	metrics        metrics.Metrics
+	logger         ctxd.Logger
 
 	transactionsPageSize int64
+	categoriesLanguage   string
+	attachmentMaxSize    int64
 }
 
 // DeviceID returns device ID.
@@ -57,6 +71,18 @@ func (c *Client) DeviceID() uuid.UUID {
 	return c.config.deviceID
 }
 
+// UserID returns the ID of the user who logged in. It is empty if the token is not obtained by logging in, for example
+// when it is loaded from the token storage or provided by another auth.TokenProvider. Use Client.Me to get it instead.
+func (c *Client) UserID() uuid.UUID {
+	return c.apiToken.UserID()
+}
+
+// TokenSource exposes the token providers of the client as an oauth2.TokenSource, for example to share the login with
+// another HTTP client. The context is used for all the tokens.
+func (c *Client) TokenSource(ctx context.Context) oauth2.TokenSource {
+	return auth.OAuth2TokenSource(ctx, c.token)
+}
+
 // NewClient initiates a new transaction.Finder.
 func NewClient(options ...Option) *Client {
 	c := &Client{
@@ -72,6 +98,9 @@ func NewClient(options ...Option) *Client {
 			mfaWait:    5 * time.Second,
 
 			transactionsPageSize: DefaultPageSize,
+			attachmentMaxSize:    DefaultAttachmentMaxSize,
+
+			metrics: metrics.Discard(),
 		},
 
 		token: newChainTokenProvider(),
@@ -83,20 +112,27 @@ func NewClient(options ...Option) *Client {
 	}
 
 	c.config.deviceID = deviceID(c.config.deviceID)
-	c.token.append(initAPITokenProvider(c.config, c.clock))
+	c.apiToken = initAPITokenProvider(c.config, c.clock)
+	c.token.append(c.apiToken)
 	c.api = initAPIClient(c.config, c.token)
+	c.http = initHTTPClient(c.config, c.token)
 
 	return c
 }
 
-func initAPITokenProvider(cfg *config, c clock.Clock) auth.TokenProvider {
+func initAPITokenProvider(cfg *config, c clock.Clock) *apiTokenProvider {
 	cfg.credentials.prepend(Credentials(cfg.username, cfg.password))
+	cfg.credentials.setBaseURL(cfg.baseURL)
 
 	apiToken := newAPITokenProvider(cfg.credentials, cfg.deviceID).
 		WithBaseURL(cfg.baseURL).
 		WithTimeout(cfg.timeout).
 		WithMFATimeout(cfg.mfaTimeout).
 		WithMFAWait(cfg.mfaWait).
+		WithMFAProgress(cfg.mfaProgress).
+		WithTracerProvider(cfg.tracerProvider).
+		WithMetrics(cfg.metrics).
+		WithLogger(cfg.logger).
 		WithTransport(cfg.transport).
 		WithClock(c)
 
@@ -112,7 +148,34 @@ func initAPIClient(cfg *config, p auth.TokenProvider) *api.Client {
 	c.BaseURL = cfg.baseURL
 	c.Timeout = cfg.timeout
 
-	c.SetTransport(TokenRoundTripper(p, cfg.transport))
+	c.SetTransport(initTransport(cfg, p))
+	c.InstrumentCtxFunc = instrumentCtx
 
 	return c
 }
+
+func initHTTPClient(cfg *config, p auth.TokenProvider) *http.Client {
+	return &http.Client{
+		Transport: initTransport(cfg, p),
+		Timeout:   cfg.timeout,
+	}
+}
+
+// initTransport authenticates the requests, the requests are traced from before the authentication so that the login is
+// a child of the request that needs it, and the metrics observe the requests without the authentication. The requests
+// are logged with the authorization header, which is redacted.
+func initTransport(cfg *config, p auth.TokenProvider) http.RoundTripper {
+	transport := cfg.transport
+
+	if cfg.logger != nil {
+		transport = loggingRoundTripper(cfg.logger, transport)
+	}
+
+	transport = TokenRoundTripper(p, metricsRoundTripper(cfg.metrics, transport))
+
+	if cfg.tracerProvider != nil {
+		transport = tracingRoundTripper(newTracer(cfg.tracerProvider), transport)
+	}
+
+	return transport
+}
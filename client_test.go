@@ -1,13 +1,21 @@
 package n26api_test
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/auth"
+	"github.com/nhatthm/n26api/pkg/device"
 	"github.com/nhatthm/n26api/pkg/testkit"
+	authMock "github.com/nhatthm/n26api/pkg/testkit/auth"
 )
 
 var (
@@ -19,6 +27,17 @@ func mockServer(deviceID uuid.UUID, mocks ...testkit.ServerOption) testkit.Serve
 	return testkit.MockServer(n26Username, n26Password, deviceID, mocks...)
 }
 
+// newTestClient creates a client that logs in to the mocked server with a short MFA wait.
+func newTestClient(s *testkit.Server, deviceID uuid.UUID, options ...n26api.Option) *n26api.Client {
+	return n26api.NewClient(append([]n26api.Option{
+		n26api.WithBaseURL(s.URL()),
+		n26api.WithDeviceID(deviceID),
+		n26api.WithCredentials(n26Username, n26Password),
+		n26api.WithMFAWait(5 * time.Millisecond),
+		n26api.WithMFATimeout(time.Second),
+	}, options...)...)
+}
+
 func TestClient_DeviceID(t *testing.T) {
 	t.Parallel()
 
@@ -36,3 +55,63 @@ func TestClient_DeviceID(t *testing.T) {
 		assert.Equal(t, deviceID, c.DeviceID())
 	})
 }
+
+func TestClient_TokenChainPolicy(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	broken := authMock.MockTokenProvider(func(p *authMock.TokenProvider) {
+		p.On("Token", mock.Anything).Return(auth.Token(""), errors.New("provider is broken"))
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		t.Parallel()
+
+		s := testkit.MockEmptyServer()(t)
+
+		c := n26api.NewClient(
+			n26api.WithBaseURL(s.URL()),
+			n26api.WithDeviceID(deviceID),
+			n26api.WithCredentials(n26Username, n26Password),
+			n26api.WithTokenProvider(broken(t)),
+		)
+
+		result, err := c.FindAllDevices(context.Background())
+
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "provider is broken")
+	})
+
+	t.Run("skip on error", func(t *testing.T) {
+		t.Parallel()
+
+		s := mockServer(deviceID, testkit.WithFindAllDevices([]device.Device{}))(t)
+
+		c := n26api.NewClient(
+			n26api.WithBaseURL(s.URL()),
+			n26api.WithDeviceID(deviceID),
+			n26api.WithCredentials(n26Username, n26Password),
+			n26api.WithMFAWait(5*time.Millisecond),
+			n26api.WithMFATimeout(time.Second),
+			n26api.WithTokenProvider(broken(t)),
+			n26api.WithTokenChainPolicy(n26api.ChainSkipOnError),
+		)
+
+		result, err := c.FindAllDevices(context.Background())
+		require.NoError(t, err)
+
+		assert.Empty(t, result)
+	})
+}
+
+func TestClient_TokenSource(t *testing.T) {
+	t.Parallel()
+
+	c := n26api.NewClient(n26api.WithTokenProvider(auth.StaticToken("token")))
+
+	token, err := c.TokenSource(context.Background()).Token()
+	require.NoError(t, err)
+
+	assert.Equal(t, "token", token.AccessToken)
+	assert.Equal(t, "Bearer", token.TokenType)
+}
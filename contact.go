@@ -0,0 +1,84 @@
+package n26api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/contact"
+)
+
+// ErrContactNotFound indicates that the contact does not exist.
+var ErrContactNotFound = errors.New("contact not found")
+
+var _ contact.Service = (*Client)(nil)
+
+// FindAllContacts finds all saved contacts.
+func (c *Client) FindAllContacts(ctx context.Context) ([]contact.Contact, error) {
+	res, err := c.api.GetAPISmrtContacts(ctx, api.GetAPISmrtContactsRequest{})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not find contacts")
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not find contacts: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not find contacts: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
+
+// CreateContact saves a new contact.
+func (c *Client) CreateContact(ctx context.Context, name string, account contact.Account) (*contact.Contact, error) {
+	res, err := c.api.PostAPISmrtContacts(ctx, api.PostAPISmrtContactsRequest{
+		Body: &api.ContactRequest{
+			Name:    name,
+			Account: account,
+		},
+	})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not create contact")
+	}
+
+	if res.ValueBadRequest != nil {
+		return nil, ctxd.NewError(ctx, "could not create contact: invalid contact",
+			"reason", res.ValueBadRequest.UserMessage.Detail,
+		)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not create contact: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not create contact: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
+
+// DeleteContact deletes a saved contact.
+func (c *Client) DeleteContact(ctx context.Context, id uuid.UUID) error {
+	res, err := c.api.DeleteAPISmrtContactsID(ctx, api.DeleteAPISmrtContactsIDRequest{
+		ID: id.String(),
+	})
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not delete contact", "id", id)
+	}
+
+	if res.ValueNotFound != nil {
+		return ctxd.WrapError(ctx, ErrContactNotFound, "could not delete contact", "id", id)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return ctxd.NewError(ctx, "could not delete contact: invalid token", "response", res)
+	}
+
+	return nil
+}
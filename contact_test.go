@@ -0,0 +1,182 @@
+package n26api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/contact"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestClient_FindAllContacts(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	contacts := []contact.Contact{
+		{ID: uuid.New(), Name: "John Doe", Account: contact.Account{AccountType: contact.AccountTypeSEPA, Iban: "DE89370400440532013000"}},
+	}
+
+	testCases := []struct {
+		scenario         string
+		mockServer       testkit.ServerMocker
+		expectedContacts []contact.Contact
+		expectedError    string
+	}{
+		{
+			scenario: "invalid token",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/smrt/contacts").
+					ReturnCode(http.StatusUnauthorized).
+					ReturnJSON(api.InvalidTokenError{
+						Status:           http.StatusUnauthorized,
+						Detail:           "Invalid token",
+						Error:            "invalid_token",
+						ErrorDescription: "Invalid token",
+					})
+			}),
+			expectedError: "could not find contacts: invalid token",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/smrt/contacts").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not find contacts: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:         "success",
+			mockServer:       mockServer(deviceID, testkit.WithFindAllContacts(contacts)),
+			expectedContacts: contacts,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.FindAllContacts(context.Background())
+
+			assert.Equal(t, tc.expectedContacts, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_CreateContact(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	name := "John Doe"
+	account := contact.Account{AccountType: contact.AccountTypeSEPA, Iban: "DE89370400440532013000", Bic: "COBADEFFXXX"}
+
+	testCases := []struct {
+		scenario      string
+		mockServer    testkit.ServerMocker
+		expectedError string
+	}{
+		{
+			scenario:      "bad request",
+			mockServer:    mockServer(deviceID, testkit.WithCreateContactFailureBadRequest(name, account)),
+			expectedError: "could not create contact: invalid contact",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPost("/api/smrt/contacts").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not create contact: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:   "success",
+			mockServer: mockServer(deviceID, testkit.WithCreateContactSuccess(name, account)),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.CreateContact(context.Background(), name, account)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				assert.NotEqual(t, uuid.UUID{}, result.ID)
+				assert.Equal(t, s.UserID(), result.UserID)
+				assert.Equal(t, name, result.Name)
+				assert.Equal(t, account, result.Account)
+			} else {
+				assert.Nil(t, result)
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_DeleteContact(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockServer    testkit.ServerMocker
+		expectedError string
+	}{
+		{
+			scenario:      "not found",
+			mockServer:    mockServer(deviceID, testkit.WithDeleteContactFailureNotFound(id)),
+			expectedError: "could not delete contact: contact not found",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectDelete("/api/smrt/contacts/" + id.String()).
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not delete contact: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:   "success",
+			mockServer: mockServer(deviceID, testkit.WithDeleteContactSuccess(id)),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			err := c.DeleteContact(context.Background(), id)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
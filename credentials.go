@@ -1,5 +1,11 @@
 package n26api
 
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
 // CredentialsProvider provides username and password for authentication.
 type CredentialsProvider interface {
 	// Username provides a username.
@@ -7,3 +13,121 @@ type CredentialsProvider interface {
 	// Password provides a password.
 	Password() string
 }
+
+// CredentialsProviderV2 provides username and password for authentication. Unlike CredentialsProvider, it can use the
+// context and report why the credentials are not available.
+type CredentialsProviderV2 interface {
+	// Credentials provides a username and a password.
+	Credentials(ctx context.Context) (username, password string, err error)
+}
+
+// CredentialsFunc is a CredentialsProviderV2 function.
+type CredentialsFunc func(ctx context.Context) (username, password string, err error)
+
+// Credentials satisfies CredentialsProviderV2.
+func (f CredentialsFunc) Credentials(ctx context.Context) (string, string, error) {
+	return f(ctx)
+}
+
+var (
+	_ CredentialsProviderV2 = (*credentialsProviderV2)(nil)
+	_ CredentialsProvider   = (*credentialsProviderV1)(nil)
+	_ CredentialsProviderV2 = (*credentialsProviderV1)(nil)
+)
+
+// credentialsProviderV2 adapts a CredentialsProvider to a CredentialsProviderV2.
+type credentialsProviderV2 struct {
+	CredentialsProvider
+}
+
+// Credentials provides a username and a password from the CredentialsProvider, it never fails.
+func (p credentialsProviderV2) Credentials(context.Context) (string, string, error) {
+	return p.Username(), p.Password(), nil
+}
+
+// credentialsProviderV1 adapts a CredentialsProviderV2 to a CredentialsProvider. The username and the password are
+// fetched in one call: the password that comes with the username is kept for the next call of Password.
+type credentialsProviderV1 struct {
+	CredentialsProviderV2
+
+	password *string
+	mu       sync.Mutex
+}
+
+// Username provides a username from the CredentialsProviderV2, it is empty if the provider fails.
+func (p *credentialsProviderV1) Username() string {
+	username, password, _ := p.Credentials(context.Background()) // nolint:errcheck
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.password = &password
+
+	return username
+}
+
+// Password provides a password from the CredentialsProviderV2, it is empty if the provider fails.
+func (p *credentialsProviderV1) Password() string {
+	p.mu.Lock()
+
+	if password := p.password; password != nil {
+		p.password = nil
+		p.mu.Unlock()
+
+		return *password
+	}
+
+	p.mu.Unlock()
+
+	_, password, _ := p.Credentials(context.Background()) // nolint:dogsled
+
+	return password
+}
+
+// downgradeCredentialsProvider adapts a CredentialsProviderV2 to a CredentialsProvider.
+func downgradeCredentialsProvider(p CredentialsProviderV2) *credentialsProviderV1 {
+	return &credentialsProviderV1{CredentialsProviderV2: p}
+}
+
+// credentialsProviderName describes a provider in the errors, the adapters are described by the adapted provider.
+func credentialsProviderName(p interface{}) string {
+	switch p := p.(type) {
+	case *credentialsProviderV1:
+		return credentialsProviderName(p.CredentialsProviderV2)
+
+	case credentialsProviderV2:
+		return credentialsProviderName(p.CredentialsProvider)
+	}
+
+	return fmt.Sprintf("%T", p)
+}
+
+// baseURLSetter is a credentials provider that depends on the base URL of the client, for example the credentials
+// helper command that is asked for the credentials of the host.
+type baseURLSetter interface {
+	setBaseURL(baseURL string)
+}
+
+// setCredentialsBaseURL sets the base URL of the client to the provider if it depends on it.
+func setCredentialsBaseURL(p interface{}, baseURL string) {
+	switch p := p.(type) {
+	case baseURLSetter:
+		p.setBaseURL(baseURL)
+
+	case *credentialsProviderV1:
+		setCredentialsBaseURL(p.CredentialsProviderV2, baseURL)
+
+	case credentialsProviderV2:
+		setCredentialsBaseURL(p.CredentialsProvider, baseURL)
+	}
+}
+
+// UpgradeCredentialsProvider adapts a CredentialsProvider to a CredentialsProviderV2. If the provider already
+// implements CredentialsProviderV2, it is returned as is.
+func UpgradeCredentialsProvider(p CredentialsProvider) CredentialsProviderV2 {
+	if v2, ok := p.(CredentialsProviderV2); ok {
+		return v2
+	}
+
+	return credentialsProviderV2{CredentialsProvider: p}
+}
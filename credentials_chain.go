@@ -1,6 +1,15 @@
 package n26api
 
-var _ CredentialsProvider = (*chainCredentialsProvider)(nil)
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	_ CredentialsProvider   = (*chainCredentialsProvider)(nil)
+	_ CredentialsProviderV2 = (*chainCredentialsProvider)(nil)
+)
 
 type chainCredentialsProvider []CredentialsProvider
 
@@ -26,6 +35,42 @@ func (chain *chainCredentialsProvider) Password() string {
 	return ""
 }
 
+// Credentials provides the username and the password of the first provider that provides both. If no provider
+// provides both, the first non-empty username is returned with the errors of the providers, each error is prefixed by
+// the type of its provider.
+func (chain *chainCredentialsProvider) Credentials(ctx context.Context) (string, string, error) {
+	var (
+		username string
+		errs     []error
+	)
+
+	for _, p := range *chain {
+		u, pwd, err := UpgradeCredentialsProvider(p).Credentials(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", credentialsProviderName(p), err))
+
+			continue
+		}
+
+		if u != "" && pwd != "" {
+			return u, pwd, nil
+		}
+
+		if username == "" {
+			username = u
+		}
+	}
+
+	return username, "", errors.Join(errs...)
+}
+
+// setBaseURL sets the base URL of the client to the providers that depend on it.
+func (chain *chainCredentialsProvider) setBaseURL(baseURL string) {
+	for _, p := range *chain {
+		setCredentialsBaseURL(p, baseURL)
+	}
+}
+
 // append appends a new provider to the chain.
 func (chain *chainCredentialsProvider) append(provider CredentialsProvider) {
 	*chain = append(*chain, provider)
@@ -52,3 +97,11 @@ func chainCredentialsProviders(providers ...CredentialsProvider) *chainCredentia
 
 	return chain
 }
+
+// ChainCredentialsProviders chains a list of CredentialsProvider, the first non-empty username and the first non-empty
+// password are provided. The chain also implements CredentialsProviderV2, the username and the password are then
+// provided together by the same provider, the failed providers are skipped and their errors are returned when no
+// provider provides both.
+func ChainCredentialsProviders(providers ...CredentialsProvider) CredentialsProvider {
+	return chainCredentialsProviders(providers...)
+}
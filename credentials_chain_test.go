@@ -1,6 +1,8 @@
 package n26api
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -55,7 +57,7 @@ func TestChainCredentialsProviders(t *testing.T) {
 		providers = append(providers, mocks[i](t))
 	}
 
-	p := chainCredentialsProviders(providers...)
+	p := ChainCredentialsProviders(providers...)
 
 	assert.Equal(t, "username", p.Username())
 	assert.Equal(t, "password", p.Password())
@@ -82,3 +84,117 @@ func provideCredentialsProviders() []testkit.CredentialsProviderMocker {
 		}),
 	}
 }
+
+func TestChainCredentialsProvider_Credentials(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario         string
+		providers        func(t *testing.T) []CredentialsProvider
+		expectedUsername string
+		expectedPassword string
+		expectedError    string
+	}{
+		{
+			scenario: "no provider",
+			providers: func(*testing.T) []CredentialsProvider {
+				return nil
+			},
+		},
+		{
+			scenario: "credentials are provided by the same provider",
+			providers: func(*testing.T) []CredentialsProvider {
+				return []CredentialsProvider{
+					Credentials("", ""),
+					Credentials("john.doe", ""),
+					Credentials("", "secret"),
+					Credentials("username", "password"),
+				}
+			},
+			expectedUsername: "username",
+			expectedPassword: "password",
+		},
+		{
+			scenario: "incomplete credentials are not mixed",
+			providers: func(*testing.T) []CredentialsProvider {
+				return []CredentialsProvider{
+					Credentials("", "secret"),
+					Credentials("username", ""),
+					Credentials("john.doe", ""),
+				}
+			},
+			expectedUsername: "username",
+		},
+		{
+			scenario: "errors are ignored when the credentials are found",
+			providers: func(t *testing.T) []CredentialsProvider { // nolint: thelper
+				return []CredentialsProvider{
+					downgradeCredentialsProvider(testkit.MockCredentialsProviderV2(func(p *testkit.CredentialsProviderV2) {
+						p.On("Credentials", context.Background()).
+							Return("", "", errors.New("vault is locked"))
+					})(t)),
+					Credentials("username", "password"),
+					downgradeCredentialsProvider(testkit.NoMockCredentialsProviderV2(t)),
+				}
+			},
+			expectedUsername: "username",
+			expectedPassword: "password",
+		},
+		{
+			scenario: "errors are aggregated",
+			providers: func(t *testing.T) []CredentialsProvider { // nolint: thelper
+				return []CredentialsProvider{
+					downgradeCredentialsProvider(testkit.MockCredentialsProviderV2(func(p *testkit.CredentialsProviderV2) {
+						p.On("Credentials", context.Background()).
+							Return("", "", errors.New("vault is locked"))
+					})(t)),
+					Credentials("username", ""),
+					downgradeCredentialsProvider(CredentialsFunc(func(context.Context) (string, string, error) {
+						return "", "", errors.New("keychain is not available")
+					})),
+				}
+			},
+			expectedUsername: "username",
+			expectedError:    "*testkit.CredentialsProviderV2: vault is locked\nn26api.CredentialsFunc: keychain is not available",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			p := chainCredentialsProviders(tc.providers(t)...)
+
+			username, password, err := p.Credentials(context.Background())
+
+			assert.Equal(t, tc.expectedUsername, username)
+			assert.Equal(t, tc.expectedPassword, password)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestCredentialsProviderV1(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	p := downgradeCredentialsProvider(CredentialsFunc(func(context.Context) (string, string, error) {
+		calls++
+
+		return "username", "password", nil
+	}))
+
+	// The password comes with the username.
+	assert.Equal(t, "username", p.Username())
+	assert.Equal(t, "password", p.Password())
+	assert.Equal(t, 1, calls)
+
+	assert.Equal(t, "password", p.Password())
+	assert.Equal(t, 2, calls)
+}
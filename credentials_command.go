@@ -0,0 +1,164 @@
+package n26api
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCredentialsCommandTimeout is the default timeout of a credentials helper command.
+const DefaultCredentialsCommandTimeout = 10 * time.Second
+
+var (
+	_ CredentialsProvider   = (*commandCredentialsProvider)(nil)
+	_ CredentialsProviderV2 = (*commandCredentialsProvider)(nil)
+)
+
+// commandCredentialsProvider provides username and password from an external helper command.
+type commandCredentialsProvider struct {
+	name    string
+	args    []string
+	timeout time.Duration
+	baseURL string
+
+	username string
+	password string
+	err      error
+	done     bool
+
+	mu sync.Mutex
+}
+
+// Username provides a username from the helper command.
+func (p *commandCredentialsProvider) Username() string {
+	username, _, _ := p.Credentials(context.Background()) // nolint:dogsled
+
+	return username
+}
+
+// Password provides a password from the helper command.
+func (p *commandCredentialsProvider) Password() string {
+	_, password, _ := p.Credentials(context.Background()) // nolint:dogsled
+
+	return password
+}
+
+// Credentials provides a username and a password from the helper command. Only a successful result is cached, the
+// command is run again after a failure or a timeout.
+func (p *commandCredentialsProvider) Credentials(ctx context.Context) (string, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.done {
+		return p.username, p.password, nil
+	}
+
+	p.username, p.password, p.err = p.get(ctx)
+	p.done = p.err == nil
+
+	return p.username, p.password, p.err
+}
+
+// setBaseURL sets the base URL of the client, the helper command is asked for the credentials of its host.
+func (p *commandCredentialsProvider) setBaseURL(baseURL string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.baseURL = baseURL
+}
+
+func (p *commandCredentialsProvider) get(ctx context.Context) (string, string, error) {
+	u, err := url.Parse(p.baseURL)
+	if err != nil {
+		return "", "", fmt.Errorf("could not parse base url: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, p.name, p.args...) // nolint:gosec
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("protocol=%s\nhost=%s\n\n", u.Scheme, u.Host))
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", "", fmt.Errorf("could not run credentials helper %s: timeout after %s", p.name, p.timeout)
+		}
+
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", "", fmt.Errorf("could not run credentials helper %s: %w: %s", p.name, err, msg)
+		}
+
+		return "", "", fmt.Errorf("could not run credentials helper %s: %w", p.name, err)
+	}
+
+	username, password := parseCredentialsHelperOutput(stdout.Bytes())
+
+	return username, password, nil
+}
+
+// parseCredentialsHelperOutput parses the `key=value` lines of a git credential helper, until the first empty line.
+func parseCredentialsHelperOutput(out []byte) (username, password string) {
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		if line == "" {
+			break
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "username":
+			username = value
+
+		case "password":
+			password = value
+		}
+	}
+
+	return username, password
+}
+
+// CredentialsFromCommand initiates a new credentials provider that runs an external helper command, like a git
+// credential helper: the protocol and the host of the API are written to the standard input, for example:
+//
+//	protocol=https
+//	host=api.tech26.de
+//
+// The host is the one of the base URL of the client, see WithBaseURL. The username and the password are read from the
+// `username=` and `password=` lines of the standard output.
+//
+// The command runs on the first use, and a successful result is cached for the lifetime of the provider. If the command
+// fails or does not finish in DefaultCredentialsCommandTimeout, the provider provides empty values so that the next
+// provider in the chain is used, the error is reported by CredentialsProviderV2.Credentials, and the command runs again
+// on the next use.
+func CredentialsFromCommand(name string, args ...string) CredentialsProvider {
+	return CredentialsFromCommandWithTimeout(DefaultCredentialsCommandTimeout, name, args...)
+}
+
+// CredentialsFromCommandWithTimeout initiates a new credentials provider that runs an external helper command with a
+// timeout, see CredentialsFromCommand.
+func CredentialsFromCommandWithTimeout(timeout time.Duration, name string, args ...string) CredentialsProvider {
+	return &commandCredentialsProvider{
+		name:    name,
+		args:    args,
+		timeout: timeout,
+		baseURL: BaseURL,
+	}
+}
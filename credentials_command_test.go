@@ -0,0 +1,212 @@
+package n26api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const envCredentialsHelper = "N26API_TEST_CREDENTIALS_HELPER"
+
+// TestCredentialsHelperProcess is not a real test, it is the credentials helper command run by the tests.
+func TestCredentialsHelperProcess(*testing.T) {
+	mode := os.Getenv(envCredentialsHelper)
+	if mode == "" {
+		return
+	}
+
+	defer os.Exit(0)
+
+	if counter := os.Getenv(envCredentialsHelper + "_COUNTER"); counter != "" {
+		f, err := os.OpenFile(counter, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) // nolint:gosec
+		if err == nil {
+			_, _ = f.WriteString("x")
+			_ = f.Close()
+		}
+	}
+
+	input := make(map[string]string)
+	scanner := bufio.NewScanner(os.Stdin)
+
+	for scanner.Scan() {
+		if scanner.Text() == "" {
+			break
+		}
+
+		key, value, _ := strings.Cut(scanner.Text(), "=")
+		input[key] = value
+	}
+
+	switch mode {
+	case "success":
+		if input["protocol"] != "https" || input["host"] != "api.tech26.de" {
+			fmt.Fprintf(os.Stderr, "unexpected input: %v\n", input)
+			os.Exit(1) // nolint:gocritic
+		}
+
+		fmt.Println("protocol=https")
+		fmt.Println("host=api.tech26.de")
+		fmt.Println("username=username")
+		fmt.Println("password=pass=word")
+		fmt.Println()
+		fmt.Println("username=ignored")
+
+	case "host":
+		fmt.Printf("username=%s\n", input["host"])
+		fmt.Printf("password=%s\n", input["protocol"])
+
+	case "failure":
+		fmt.Fprintln(os.Stderr, "vault is locked")
+		os.Exit(1)
+
+	case "flaky":
+		// Fails on the first run only.
+		if calls, _ := os.ReadFile(os.Getenv(envCredentialsHelper + "_COUNTER")); len(calls) < 2 { // nolint:errcheck
+			fmt.Fprintln(os.Stderr, "vault is locked")
+			os.Exit(1)
+		}
+
+		fmt.Println("username=username")
+		fmt.Println("password=password")
+
+	case "timeout":
+		time.Sleep(5 * time.Second)
+	}
+}
+
+func credentialsHelper(t *testing.T, timeout time.Duration, mode string) (*commandCredentialsProvider, string) {
+	t.Helper()
+
+	counter := filepath.Join(t.TempDir(), "counter")
+
+	t.Setenv(envCredentialsHelper, mode)
+	t.Setenv(envCredentialsHelper+"_COUNTER", counter)
+
+	p := CredentialsFromCommandWithTimeout(timeout, os.Args[0], "-test.run=^TestCredentialsHelperProcess$")
+
+	return p.(*commandCredentialsProvider), counter
+}
+
+func TestCredentialsFromCommand(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		p, counter := credentialsHelper(t, DefaultCredentialsCommandTimeout, "success")
+
+		var wg sync.WaitGroup
+
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				assert.Equal(t, "username", p.Username())
+				assert.Equal(t, "pass=word", p.Password())
+			}()
+		}
+
+		wg.Wait()
+
+		require.NoError(t, p.err)
+
+		// The helper runs only once.
+		calls, err := os.ReadFile(counter) // nolint:gosec
+		require.NoError(t, err)
+
+		assert.Equal(t, "x", string(calls))
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		p, _ := credentialsHelper(t, DefaultCredentialsCommandTimeout, "failure")
+
+		assert.Empty(t, p.Username())
+		assert.Empty(t, p.Password())
+
+		assert.EqualError(t, p.err, fmt.Sprintf("could not run credentials helper %s: exit status 1: vault is locked", os.Args[0]))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		p, _ := credentialsHelper(t, 100*time.Millisecond, "timeout")
+
+		assert.Empty(t, p.Username())
+		assert.Empty(t, p.Password())
+
+		assert.EqualError(t, p.err, fmt.Sprintf("could not run credentials helper %s: timeout after 100ms", os.Args[0]))
+	})
+
+	t.Run("cancelled context is not cached", func(t *testing.T) {
+		p, counter := credentialsHelper(t, DefaultCredentialsCommandTimeout, "success")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		username, password, err := p.Credentials(ctx)
+
+		assert.Empty(t, username)
+		assert.Empty(t, password)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		assert.Equal(t, "username", p.Username())
+		assert.Equal(t, "pass=word", p.Password())
+
+		calls, err := os.ReadFile(counter) // nolint:gosec
+		require.NoError(t, err)
+
+		assert.Equal(t, "x", string(calls))
+	})
+
+	t.Run("failure is not cached", func(t *testing.T) {
+		p, counter := credentialsHelper(t, DefaultCredentialsCommandTimeout, "flaky")
+
+		username, password, err := p.Credentials(context.Background())
+
+		assert.Empty(t, username)
+		assert.Empty(t, password)
+		assert.EqualError(t, err, fmt.Sprintf("could not run credentials helper %s: exit status 1: vault is locked", os.Args[0]))
+
+		assert.Equal(t, "username", p.Username())
+		assert.Equal(t, "password", p.Password())
+
+		// The helper does not run again after a success.
+		calls, err := os.ReadFile(counter) // nolint:gosec
+		require.NoError(t, err)
+
+		assert.Equal(t, "xx", string(calls))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		p := CredentialsFromCommand(filepath.Join(t.TempDir(), "missing")).(*commandCredentialsProvider)
+
+		assert.Empty(t, p.Username())
+		assert.Contains(t, p.err.Error(), "no such file or directory")
+	})
+
+	t.Run("base url", func(t *testing.T) {
+		p, _ := credentialsHelper(t, DefaultCredentialsCommandTimeout, "host")
+
+		_ = NewClient(
+			WithBaseURL("http://localhost:8080"),
+			WithCredentialsProvider(ChainCredentialsProviders(p)),
+		)
+
+		assert.Equal(t, "localhost:8080", p.Username())
+		assert.Equal(t, "http", p.Password())
+	})
+
+	t.Run("chained", func(t *testing.T) {
+		p, _ := credentialsHelper(t, DefaultCredentialsCommandTimeout, "failure")
+
+		chain := chainCredentialsProviders(p, Credentials("fallback", "secret"))
+
+		assert.Equal(t, "fallback", chain.Username())
+		assert.Equal(t, "secret", chain.Password())
+	})
+}
@@ -0,0 +1,81 @@
+package n26api
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	envUsernameFile = "N26_USERNAME_FILE"
+	envPasswordFile = "N26_PASSWORD_FILE"
+)
+
+// fileCredentials is the content of a credentials file.
+type fileCredentials struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// readSecretFile reads a file that contains only a secret, the trailing line break is removed.
+func readSecretFile(path string) (string, error) {
+	b, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
+
+// CredentialsFromEnvFiles initiates a new credentials provider that reads the username and the password from the
+// files at the paths in the N26_USERNAME_FILE and N26_PASSWORD_FILE environment variables, for example, Docker or
+// Kubernetes secrets. The files are read once, an unset variable provides an empty value. The client uses the username
+// and the password of the same provider, if only one of them is provided, the next provider in the chain is used.
+func CredentialsFromEnvFiles() (CredentialsProvider, error) {
+	p := &configCredentialsProvider{}
+
+	if path := os.Getenv(envUsernameFile); path != "" {
+		username, err := readSecretFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read username file from %s: %w", envUsernameFile, err)
+		}
+
+		p.username = username
+	}
+
+	if path := os.Getenv(envPasswordFile); path != "" {
+		password, err := readSecretFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not read password file from %s: %w", envPasswordFile, err)
+		}
+
+		p.password = password
+	}
+
+	return p, nil
+}
+
+// CredentialsFromFile initiates a new credentials provider that reads the username and the password from a YAML or a
+// JSON file, for example:
+//
+//	username: john.doe@example.com
+//	password: secret
+//
+// The file is read once.
+func CredentialsFromFile(path string) (CredentialsProvider, error) {
+	b, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("could not read credentials file: %w", err)
+	}
+
+	var c fileCredentials
+
+	// JSON is a subset of YAML.
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("could not parse credentials file %s: %w", path, err)
+	}
+
+	return Credentials(c.Username, c.Password), nil
+}
@@ -0,0 +1,184 @@
+package n26api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/device"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+const (
+	envUsernameFile = "N26_USERNAME_FILE"
+	envPasswordFile = "N26_PASSWORD_FILE"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestCredentialsFromEnvFiles(t *testing.T) {
+	t.Run("files", func(t *testing.T) {
+		t.Setenv(envUsernameFile, writeFile(t, "username", "username\n"))
+		t.Setenv(envPasswordFile, writeFile(t, "password", "pass word\r\n"))
+
+		p, err := n26api.CredentialsFromEnvFiles()
+		require.NoError(t, err)
+
+		assert.Equal(t, "username", p.Username())
+		assert.Equal(t, "pass word", p.Password())
+	})
+
+	t.Run("no files", func(t *testing.T) {
+		t.Setenv(envUsernameFile, "")
+		t.Setenv(envPasswordFile, "")
+
+		p, err := n26api.CredentialsFromEnvFiles()
+		require.NoError(t, err)
+
+		assert.Empty(t, p.Username())
+		assert.Empty(t, p.Password())
+	})
+
+	t.Run("missing username file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "username")
+
+		t.Setenv(envUsernameFile, path)
+		t.Setenv(envPasswordFile, "")
+
+		p, err := n26api.CredentialsFromEnvFiles()
+
+		assert.Nil(t, p)
+		assert.EqualError(t, err, "could not read username file from N26_USERNAME_FILE: open "+path+": no such file or directory")
+		assert.True(t, errors.Is(err, fs.ErrNotExist))
+	})
+
+	t.Run("missing password file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "password")
+
+		t.Setenv(envUsernameFile, "")
+		t.Setenv(envPasswordFile, path)
+
+		p, err := n26api.CredentialsFromEnvFiles()
+
+		assert.Nil(t, p)
+		assert.EqualError(t, err, "could not read password file from N26_PASSWORD_FILE: open "+path+": no such file or directory")
+	})
+
+	t.Run("chained", func(t *testing.T) {
+		t.Setenv(envUsername, "env-username")
+		t.Setenv(envPassword, "env-password")
+		t.Setenv(envUsernameFile, writeFile(t, "username", "file-username"))
+		t.Setenv(envPasswordFile, writeFile(t, "password", "file-password"))
+
+		p, err := n26api.CredentialsFromEnvFiles()
+		require.NoError(t, err)
+
+		deviceID := uuid.New()
+		s := testkit.MockServer("file-username", "file-password", deviceID, testkit.WithFindAllDevices([]device.Device{}))(t)
+
+		c := n26api.NewClient(
+			n26api.WithBaseURL(s.URL()),
+			n26api.WithDeviceID(deviceID),
+			n26api.WithCredentialsProvider(p),
+			n26api.WithMFAWait(5*time.Millisecond),
+			n26api.WithMFATimeout(time.Second),
+		)
+
+		_, err = c.FindAllDevices(context.Background())
+
+		assert.NoError(t, err)
+	})
+}
+
+func TestCredentialsFromFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario         string
+		name             string
+		content          string
+		expectedUsername string
+		expectedPassword string
+		expectedError    string
+	}{
+		{
+			scenario:         "yaml",
+			name:             "credentials.yaml",
+			content:          "username: username\npassword: \"p@ss: word\"\n",
+			expectedUsername: "username",
+			expectedPassword: "p@ss: word",
+		},
+		{
+			scenario:         "json",
+			name:             "credentials.json",
+			content:          `{"username": "username", "password": "password"}`,
+			expectedUsername: "username",
+			expectedPassword: "password",
+		},
+		{
+			scenario:         "only username",
+			name:             "credentials.yml",
+			content:          "username: username\n",
+			expectedUsername: "username",
+		},
+		{
+			scenario:      "invalid file",
+			name:          "credentials.yaml",
+			content:       "username: [",
+			expectedError: "could not parse credentials file %s: yaml: line 1: did not find expected node content",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			path := writeFile(t, tc.name, tc.content)
+
+			p, err := n26api.CredentialsFromFile(path)
+
+			if tc.expectedError != "" {
+				assert.Nil(t, p)
+				assert.EqualError(t, err, fmt.Sprintf(tc.expectedError, path))
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedUsername, p.Username())
+			assert.Equal(t, tc.expectedPassword, p.Password())
+		})
+	}
+}
+
+func TestCredentialsFromFile_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "credentials.yaml")
+
+	p, err := n26api.CredentialsFromFile(path)
+
+	assert.Nil(t, p)
+	assert.EqualError(t, err, "could not read credentials file: open "+path+": no such file or directory")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
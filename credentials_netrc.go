@@ -0,0 +1,206 @@
+package n26api
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+const envNetrc = "NETRC"
+
+// netrcMachine is a machine entry of a netrc file.
+type netrcMachine struct {
+	name     string
+	login    string
+	password string
+}
+
+// defaultNetrcPath returns the path in the NETRC environment variable, or the .netrc (_netrc on Windows) file in the
+// home directory.
+func defaultNetrcPath() (string, error) {
+	if path := os.Getenv(envNetrc); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	name := ".netrc"
+
+	if runtime.GOOS == "windows" {
+		name = "_netrc"
+	}
+
+	return filepath.Join(home, name), nil
+}
+
+// parseNetrc parses the machine entries of a netrc file, the "default" entry has an empty name. Macro definitions are
+// skipped.
+func parseNetrc(data []byte) []netrcMachine {
+	var (
+		machines []netrcMachine
+		current  *netrcMachine
+		inMacro  bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// A macro definition ends with an empty line.
+		if inMacro {
+			inMacro = strings.TrimSpace(line) != ""
+
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		for i := 0; i < len(fields); i++ {
+			value := func() string {
+				if i+1 >= len(fields) {
+					return ""
+				}
+
+				i++
+
+				return fields[i]
+			}
+
+			switch fields[i] {
+			case "machine":
+				machines = append(machines, netrcMachine{name: value()})
+				current = &machines[len(machines)-1]
+
+			case "default":
+				machines = append(machines, netrcMachine{})
+				current = &machines[len(machines)-1]
+
+			case "login":
+				if login := value(); current != nil {
+					current.login = login
+				}
+
+			case "password":
+				if password := value(); current != nil {
+					current.password = password
+				}
+
+			case "account":
+				_ = value()
+
+			case "macdef":
+				inMacro = true
+				i = len(fields)
+			}
+		}
+	}
+
+	return machines
+}
+
+// netrcCredentialsProvider provides username and password from the machine entries of a netrc file.
+type netrcCredentialsProvider struct {
+	machines []netrcMachine
+	host     string
+	baseURL  string
+
+	mu sync.Mutex
+}
+
+// Username provides the login of the host.
+func (p *netrcCredentialsProvider) Username() string {
+	return p.machine().login
+}
+
+// Password provides the password of the host.
+func (p *netrcCredentialsProvider) Password() string {
+	return p.machine().password
+}
+
+// setBaseURL sets the base URL of the client, its host is used when no host is given.
+func (p *netrcCredentialsProvider) setBaseURL(baseURL string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.baseURL = baseURL
+}
+
+// machine finds the entry of the host, or the "default" entry.
+func (p *netrcCredentialsProvider) machine() netrcMachine {
+	host := p.resolveHost()
+
+	var fallback *netrcMachine
+
+	for i, m := range p.machines {
+		if m.name == host {
+			return m
+		}
+
+		if m.name == "" && fallback == nil {
+			fallback = &p.machines[i]
+		}
+	}
+
+	if fallback != nil {
+		return *fallback
+	}
+
+	return netrcMachine{}
+}
+
+// resolveHost returns the given host, or the host of the base URL of the client.
+func (p *netrcCredentialsProvider) resolveHost() string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.host != "" {
+		return p.host
+	}
+
+	u, err := url.Parse(p.baseURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Hostname()
+}
+
+// CredentialsFromNetrc initiates a new credentials provider that reads the username (login) and the password of the
+// API host from a netrc file, for example:
+//
+//	machine api.tech26.de login john.doe@example.com password secret
+//
+// If the path is empty, the file in the NETRC environment variable or the .netrc file in the home directory is used.
+// If the host is empty, the host of the base URL of the client is used, see WithBaseURL. The "default" entry is used
+// when there is no entry for the host. The file is read once.
+func CredentialsFromNetrc(path string, host string) (CredentialsProvider, error) {
+	if path == "" {
+		p, err := defaultNetrcPath()
+		if err != nil {
+			return nil, fmt.Errorf("could not find netrc file: %w", err)
+		}
+
+		path = p
+	}
+
+	data, err := os.ReadFile(path) // nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("could not read netrc file: %w", err)
+	}
+
+	return &netrcCredentialsProvider{
+		machines: parseNetrc(data),
+		host:     host,
+		baseURL:  BaseURL,
+	}, nil
+}
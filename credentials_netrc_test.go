@@ -0,0 +1,115 @@
+package n26api_test
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api"
+)
+
+const netrc = `# N26
+machine example.com login other password other-secret
+
+machine api.tech26.de
+    login username
+    password password
+
+macdef init
+machine api.tech26.de login macro password macro
+
+default login default password default-secret
+`
+
+func TestCredentialsFromNetrc(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario         string
+		content          string
+		host             string
+		expectedUsername string
+		expectedPassword string
+	}{
+		{
+			scenario:         "default host",
+			content:          netrc,
+			expectedUsername: "username",
+			expectedPassword: "password",
+		},
+		{
+			scenario:         "custom host",
+			content:          netrc,
+			host:             "example.com",
+			expectedUsername: "other",
+			expectedPassword: "other-secret",
+		},
+		{
+			scenario:         "default entry",
+			content:          netrc,
+			host:             "unknown.example.com",
+			expectedUsername: "default",
+			expectedPassword: "default-secret",
+		},
+		{
+			scenario: "no entry",
+			content:  "machine example.com login other password other-secret\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			p, err := n26api.CredentialsFromNetrc(writeFile(t, ".netrc", tc.content), tc.host)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedUsername, p.Username())
+			assert.Equal(t, tc.expectedPassword, p.Password())
+		})
+	}
+}
+
+func TestCredentialsFromNetrc_EnvPath(t *testing.T) {
+	t.Setenv("NETRC", writeFile(t, ".netrc", netrc))
+
+	p, err := n26api.CredentialsFromNetrc("", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, "username", p.Username())
+	assert.Equal(t, "password", p.Password())
+}
+
+func TestCredentialsFromNetrc_BaseURL(t *testing.T) {
+	t.Parallel()
+
+	content := netrc + "machine localhost login local password local-secret\n"
+
+	p, err := n26api.CredentialsFromNetrc(writeFile(t, ".netrc", content), "")
+	require.NoError(t, err)
+
+	_ = n26api.NewClient(
+		n26api.WithBaseURL("http://localhost:8080"),
+		n26api.WithCredentialsProvider(p),
+	)
+
+	assert.Equal(t, "local", p.Username())
+	assert.Equal(t, "local-secret", p.Password())
+}
+
+func TestCredentialsFromNetrc_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), ".netrc")
+
+	p, err := n26api.CredentialsFromNetrc(path, "")
+
+	assert.Nil(t, p)
+	assert.EqualError(t, err, "could not read netrc file: open "+path+": no such file or directory")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
@@ -0,0 +1,120 @@
+package n26api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+var (
+	_ CredentialsProvider   = (*promptCredentialsProvider)(nil)
+	_ CredentialsProviderV2 = (*promptCredentialsProvider)(nil)
+)
+
+// promptCredentialsProvider asks for username and password.
+type promptCredentialsProvider struct {
+	in  io.Reader
+	out io.Writer
+
+	username string
+	password string
+	err      error
+	done     bool
+
+	mu sync.Mutex
+}
+
+// Username asks for a username.
+func (p *promptCredentialsProvider) Username() string {
+	username, _, _ := p.Credentials(context.Background()) // nolint:dogsled
+
+	return username
+}
+
+// Password asks for a password.
+func (p *promptCredentialsProvider) Password() string {
+	_, password, _ := p.Credentials(context.Background()) // nolint:dogsled
+
+	return password
+}
+
+// Credentials asks for a username and a password. The answers are cached, the questions are not asked if the context
+// is already done so that they are asked again with the next context.
+func (p *promptCredentialsProvider) Credentials(ctx context.Context) (string, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.done {
+		return p.username, p.password, p.err
+	}
+
+	if err := ctx.Err(); err != nil {
+		return "", "", fmt.Errorf("could not prompt for credentials: %w", err)
+	}
+
+	p.username, p.password, p.err = p.ask()
+	p.done = true
+
+	return p.username, p.password, p.err
+}
+
+func (p *promptCredentialsProvider) ask() (string, string, error) {
+	r := bufio.NewReader(p.in)
+
+	_, _ = fmt.Fprint(p.out, "N26 username: ")
+
+	username, err := readLine(r)
+	if err != nil {
+		return "", "", fmt.Errorf("could not prompt for username: %w", err)
+	}
+
+	_, _ = fmt.Fprint(p.out, "N26 password: ")
+
+	var password string
+
+	if f, ok := p.in.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		b, err := term.ReadPassword(int(f.Fd()))
+
+		// The line break is not echoed either.
+		_, _ = fmt.Fprintln(p.out)
+
+		if err != nil {
+			return "", "", fmt.Errorf("could not prompt for password: %w", err)
+		}
+
+		password = string(b)
+	} else if password, err = readLine(r); err != nil {
+		return "", "", fmt.Errorf("could not prompt for password: %w", err)
+	}
+
+	return username, password, nil
+}
+
+// readLine reads a line without the line break, the last line does not need a line break.
+func readLine(r *bufio.Reader) (string, error) {
+	line, err := r.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") { // nolint:errorlint
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// CredentialsFromPrompt initiates a new credentials provider that asks for the username and the password. The
+// password is not echoed if the input is a terminal, for example os.Stdin.
+//
+// The questions are asked once, on the first use, and the answers are cached for the lifetime of the provider. If the
+// answers can not be read, the provider provides empty values so that the next provider in the chain is used, the
+// error is reported by CredentialsProviderV2.Credentials.
+func CredentialsFromPrompt(in io.Reader, out io.Writer) CredentialsProvider {
+	return &promptCredentialsProvider{
+		in:  in,
+		out: out,
+	}
+}
@@ -0,0 +1,133 @@
+package n26api_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/device"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestCredentialsFromPrompt(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario         string
+		input            string
+		expectedUsername string
+		expectedPassword string
+		expectedOutput   string
+	}{
+		{
+			scenario:         "success",
+			input:            "username\npassword\n",
+			expectedUsername: "username",
+			expectedPassword: "password",
+			expectedOutput:   "N26 username: N26 password: ",
+		},
+		{
+			scenario:         "windows line breaks",
+			input:            "username\r\npass word\r\n",
+			expectedUsername: "username",
+			expectedPassword: "pass word",
+			expectedOutput:   "N26 username: N26 password: ",
+		},
+		{
+			scenario:         "no trailing line break",
+			input:            "username\npassword",
+			expectedUsername: "username",
+			expectedPassword: "password",
+			expectedOutput:   "N26 username: N26 password: ",
+		},
+		{
+			scenario:       "no password",
+			input:          "username\n",
+			expectedOutput: "N26 username: N26 password: ",
+		},
+		{
+			scenario:       "no input",
+			expectedOutput: "N26 username: ",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			out := new(bytes.Buffer)
+			p := n26api.CredentialsFromPrompt(strings.NewReader(tc.input), out)
+
+			assert.Equal(t, tc.expectedUsername, p.Username())
+			assert.Equal(t, tc.expectedPassword, p.Password())
+
+			// Only asked once.
+			assert.Equal(t, tc.expectedUsername, p.Username())
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+}
+
+func TestMFAProgressPrinter(t *testing.T) {
+	t.Parallel()
+
+	out := new(bytes.Buffer)
+	p := n26api.MFAProgressPrinter(out)
+
+	p(12*time.Second + 400*time.Millisecond)
+	p(-time.Second)
+
+	expected := "approve the login on your phone… 12s left\napprove the login on your phone… 0s left\n"
+
+	assert.Equal(t, expected, out.String())
+}
+
+func TestWithMFAProgress(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+
+	s := testkit.MockEmptyServer(
+		testkit.WithAuthPasswordLoginSuccess(n26Username, n26Password, deviceID),
+		testkit.WithAuthMFAChallengeSuccess(),
+		testkit.WithAuthConfirmLoginFailureInvalidToken(2),
+		testkit.WithAuthConfirmLoginSuccess(),
+		testkit.WithFindAllDevices([]device.Device{}),
+	)(t)
+
+	var remaining []time.Duration
+
+	c := n26api.NewClient(
+		n26api.WithBaseURL(s.URL()),
+		n26api.WithDeviceID(deviceID),
+		n26api.WithCredentialsProvider(n26api.CredentialsFromPrompt(strings.NewReader(n26Username+"\n"+n26Password+"\n"), new(bytes.Buffer))),
+		n26api.WithMFAWait(5*time.Millisecond),
+		n26api.WithMFATimeout(time.Minute),
+		n26api.WithMFAProgress(func(d time.Duration) {
+			remaining = append(remaining, d)
+		}),
+	)
+
+	_, err := c.FindAllDevices(context.Background())
+	require.NoError(t, err)
+
+	// After the challenge and after the 2 unconfirmed attempts.
+	require.Len(t, remaining, 3)
+
+	for i, d := range remaining {
+		assert.LessOrEqual(t, d, time.Minute)
+		assert.Greater(t, d, 50*time.Second)
+
+		if i > 0 {
+			assert.Less(t, d, remaining[i-1])
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package n26api_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/device"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestUpgradeCredentialsProvider(t *testing.T) {
+	t.Parallel()
+
+	p := n26api.UpgradeCredentialsProvider(n26api.Credentials("username", "password"))
+
+	username, password, err := p.Credentials(context.Background())
+
+	assert.Equal(t, "username", username)
+	assert.Equal(t, "password", password)
+	assert.NoError(t, err)
+}
+
+func TestUpgradeCredentialsProvider_V2(t *testing.T) {
+	t.Parallel()
+
+	p := n26api.UpgradeCredentialsProvider(n26api.CredentialsFromCommand("n26api-missing-credentials-helper"))
+
+	username, password, err := p.Credentials(context.Background())
+
+	assert.Empty(t, username)
+	assert.Empty(t, password)
+	assert.EqualError(t, err, `could not run credentials helper n26api-missing-credentials-helper: exec: "n26api-missing-credentials-helper": executable file not found in $PATH`)
+}
+
+func TestWithCredentialsProviderV2_OneCallPerLogin(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	s := testkit.MockServer("username", "password", deviceID, testkit.WithFindAllDevices([]device.Device{}))(t)
+
+	var calls int32
+
+	c := n26api.NewClient(
+		n26api.WithBaseURL(s.URL()),
+		n26api.WithDeviceID(deviceID),
+		n26api.WithCredentialsProviderV2(n26api.CredentialsFunc(func(context.Context) (string, string, error) {
+			atomic.AddInt32(&calls, 1)
+
+			return "username", "password", nil
+		})),
+		n26api.WithMFAWait(5*time.Millisecond),
+		n26api.WithMFATimeout(time.Second),
+	)
+
+	_, err := c.FindAllDevices(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestCredentialsFunc(t *testing.T) {
+	t.Parallel()
+
+	p := n26api.CredentialsFunc(func(context.Context) (string, string, error) {
+		return "", "", errors.New("vault is locked")
+	})
+
+	username, password, err := p.Credentials(context.Background())
+
+	assert.Empty(t, username)
+	assert.Empty(t, password)
+	assert.EqualError(t, err, "vault is locked")
+}
+
+func TestWithCredentialsProviderV2(t *testing.T) {
+	t.Parallel()
+
+	c := n26api.NewClient(
+		n26api.WithBaseURL("http://localhost"),
+		n26api.WithCredentialsProviderV2(n26api.CredentialsFunc(func(context.Context) (string, string, error) {
+			return "", "", errors.New("vault is locked")
+		})),
+		n26api.WithCredentialsProviderAtLast(n26api.Credentials("", "")),
+	)
+
+	_, err := c.FindAllDevices(context.Background())
+
+	// The env credentials are not set in tests.
+	assert.ErrorIs(t, err, n26api.ErrUsernameIsEmpty)
+	assert.ErrorContains(t, err, "vault is locked")
+}
@@ -0,0 +1,61 @@
+package n26api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/device"
+)
+
+// ErrDeviceNotFound indicates that the device is not paired.
+var ErrDeviceNotFound = errors.New("device not found")
+
+var _ device.Service = (*Client)(nil)
+
+// FindAllDevices finds all paired devices.
+func (c *Client) FindAllDevices(ctx context.Context) ([]device.Device, error) {
+	res, err := c.api.GetAPIMeDevices(ctx, api.GetAPIMeDevicesRequest{})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not find devices")
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not find devices: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not find devices: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
+
+// UnpairDevice unpairs a device.
+func (c *Client) UnpairDevice(ctx context.Context, deviceToken uuid.UUID) error {
+	res, err := c.api.DeleteAPIMeDevicesDeviceToken(ctx, api.DeleteAPIMeDevicesDeviceTokenRequest{
+		DeviceToken: deviceToken.String(),
+	})
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not unpair device", "device_token", deviceToken)
+	}
+
+	if res.ValueNotFound != nil {
+		return ctxd.WrapError(ctx, ErrDeviceNotFound, "could not unpair device", "device_token", deviceToken)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return ctxd.NewError(ctx, "could not unpair device: invalid token", "response", res)
+	}
+
+	return nil
+}
+
+// PruneDevices unpairs all the devices except the one of the client, see Client.DeviceID. It returns the unpaired
+// devices.
+func (c *Client) PruneDevices(ctx context.Context) ([]device.Device, error) {
+	return device.PruneDevices(ctx, c, c.DeviceID())
+}
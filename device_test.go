@@ -0,0 +1,169 @@
+package n26api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/device"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestClient_FindAllDevices(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	devices := []device.Device{
+		{DeviceToken: deviceID, Name: "n26api", PairedTS: 1609455600000, LastLoginTS: 1617231599999},
+	}
+
+	testCases := []struct {
+		scenario        string
+		mockServer      testkit.ServerMocker
+		expectedDevices []device.Device
+		expectedError   string
+	}{
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/me/devices").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not find devices: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:        "success",
+			mockServer:      mockServer(deviceID, testkit.WithFindAllDevices(devices)),
+			expectedDevices: devices,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.FindAllDevices(context.Background())
+
+			assert.Equal(t, tc.expectedDevices, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_UnpairDevice(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	stale := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockServer    testkit.ServerMocker
+		expectedError string
+	}{
+		{
+			scenario:      "device not found",
+			mockServer:    mockServer(deviceID, testkit.WithUnpairDeviceFailureNotFound(stale)),
+			expectedError: "could not unpair device: device not found",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectDelete("/api/me/devices/" + stale.String()).
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not unpair device: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:   "success",
+			mockServer: mockServer(deviceID, testkit.WithUnpairDeviceSuccess(stale)),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			err := c.UnpairDevice(context.Background(), stale)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_PruneDevices(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	phone := device.Device{DeviceToken: uuid.New(), Name: "iPhone"}
+	own := device.Device{DeviceToken: deviceID, Name: "n26api"}
+	stale := device.Device{DeviceToken: uuid.New(), Name: "n26api"}
+
+	testCases := []struct {
+		scenario       string
+		mockServer     testkit.ServerMocker
+		expectedResult []device.Device
+		expectedError  error
+		expectedMsg    string
+	}{
+		{
+			scenario: "could not unpair device",
+			mockServer: mockServer(deviceID,
+				testkit.WithFindAllDevices([]device.Device{phone, own, stale}),
+				testkit.WithUnpairDeviceSuccess(phone.DeviceToken),
+				testkit.WithUnpairDeviceFailureNotFound(stale.DeviceToken),
+			),
+			expectedResult: []device.Device{phone},
+			expectedError:  n26api.ErrDeviceNotFound,
+			expectedMsg:    "could not prune devices: could not unpair device: device not found",
+		},
+		{
+			scenario:       "success",
+			mockServer:     mockServer(deviceID, testkit.WithPruneDevices([]device.Device{phone, own, stale})),
+			expectedResult: []device.Device{phone, stale},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.PruneDevices(context.Background())
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedMsg)
+				assert.True(t, errors.Is(err, tc.expectedError))
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package n26api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/directdebit"
+)
+
+// ErrDirectDebitNotReturnable indicates that the transaction is not a direct debit or can not be returned anymore.
+var ErrDirectDebitNotReturnable = errors.New("direct debit is not returnable")
+
+var _ directdebit.Service = (*Client)(nil)
+
+// FindAllMandates finds all SEPA direct debit mandates.
+func (c *Client) FindAllMandates(ctx context.Context) ([]directdebit.Mandate, error) {
+	res, err := c.api.GetAPISmrtDirectdebitsMandates(ctx, api.GetAPISmrtDirectdebitsMandatesRequest{})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not find mandates")
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not find mandates: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not find mandates: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
+
+// ReturnDirectDebit initiates a return of a direct debit transaction.
+func (c *Client) ReturnDirectDebit(ctx context.Context, transactionID uuid.UUID) error {
+	res, err := c.api.PostAPISmrtTransactionsIDReturn(ctx, api.PostAPISmrtTransactionsIDReturnRequest{
+		ID: transactionID.String(),
+	})
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not return direct debit", "transaction_id", transactionID)
+	}
+
+	if res.ValueBadRequest != nil {
+		return ctxd.WrapError(ctx, ErrDirectDebitNotReturnable, "could not return direct debit",
+			"transaction_id", transactionID,
+			"reason", res.ValueBadRequest.UserMessage.Detail,
+		)
+	}
+
+	if res.ValueNotFound != nil {
+		return ctxd.WrapError(ctx, ErrTransactionNotFound, "could not return direct debit", "transaction_id", transactionID)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return ctxd.NewError(ctx, "could not return direct debit: invalid token", "response", res)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package n26api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/directdebit"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestClient_FindAllMandates(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	mandates := []directdebit.Mandate{
+		{ID: uuid.New(), CreditorID: "DE98ZZZ09999999999", CreditorName: "Gym GmbH", MandateReference: "M-0001"},
+	}
+
+	testCases := []struct {
+		scenario         string
+		mockServer       testkit.ServerMocker
+		expectedMandates []directdebit.Mandate
+		expectedError    string
+	}{
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/smrt/directdebits/mandates").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not find mandates: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:         "success",
+			mockServer:       mockServer(deviceID, testkit.WithFindAllMandates(mandates)),
+			expectedMandates: mandates,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.FindAllMandates(context.Background())
+
+			assert.Equal(t, tc.expectedMandates, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_ReturnDirectDebit(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	transactionID := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockServer    testkit.ServerMocker
+		expectedError error
+		expectedMsg   string
+	}{
+		{
+			scenario:      "not returnable",
+			mockServer:    mockServer(deviceID, testkit.WithReturnDirectDebitFailureNotReturnable(transactionID)),
+			expectedError: n26api.ErrDirectDebitNotReturnable,
+			expectedMsg:   "could not return direct debit: direct debit is not returnable",
+		},
+		{
+			scenario:      "transaction not found",
+			mockServer:    mockServer(deviceID, testkit.WithReturnDirectDebitFailureNotFound(transactionID)),
+			expectedError: n26api.ErrTransactionNotFound,
+			expectedMsg:   "could not return direct debit: transaction not found",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPost("/api/smrt/transactions/" + transactionID.String() + "/return").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedMsg: "could not return direct debit: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:   "success",
+			mockServer: mockServer(deviceID, testkit.WithReturnDirectDebitSuccess(transactionID)),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			err := c.ReturnDirectDebit(context.Background(), transactionID)
+
+			if tc.expectedMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedMsg)
+			}
+
+			if tc.expectedError != nil {
+				assert.True(t, errors.Is(err, tc.expectedError))
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ package api
 
 import (
 	"time"
+
+	"github.com/nhatthm/n26api/pkg/contact"
 )
 
 // TokenResponse structure is generated from "#/components/schemas/TokenResponse".
@@ -99,3 +101,77 @@ type InvalidTokenError struct {
 	Error            string      `json:"error,omitempty"`
 	ErrorDescription string      `json:"error_description,omitempty"`
 }
+
+// TransactionMemoRequest structure is generated from "#/components/schemas/TransactionMemoRequest".
+type TransactionMemoRequest struct {
+	Memo string `json:"memo"` // Required.
+}
+
+// TransactionTagsRequest structure is generated from "#/components/schemas/TransactionTagsRequest".
+type TransactionTagsRequest struct {
+	Tags []string `json:"tags"` // Required.
+}
+
+// ContactRequest structure is generated from "#/components/schemas/ContactRequest".
+type ContactRequest struct {
+	Name    string          `json:"name"`    // Required.
+	Account contact.Account `json:"account"` // Required.
+}
+
+// BadRequestError structure is generated from "#/components/schemas/BadRequestError".
+type BadRequestError struct {
+	Status           int64       `json:"status,omitempty"`
+	Detail           string      `json:"detail,omitempty"`
+	Type             string      `json:"type,omitempty"`
+	UserMessage      UserMessage `json:"userMessage"` // Required.
+	Error            string      `json:"error,omitempty"`
+	ErrorDescription string      `json:"error_description,omitempty"`
+}
+
+// NotFoundError structure is generated from "#/components/schemas/NotFoundError".
+type NotFoundError struct {
+	Status           int64       `json:"status,omitempty"`
+	Detail           string      `json:"detail,omitempty"`
+	Type             string      `json:"type,omitempty"`
+	UserMessage      UserMessage `json:"userMessage"` // Required.
+	Error            string      `json:"error,omitempty"`
+	ErrorDescription string      `json:"error_description,omitempty"`
+}
+
+// LimitRequest structure is generated from "#/components/schemas/LimitRequest".
+type LimitRequest struct {
+	Limit       string   `json:"limit"` // Required.
+	Amount      float64  `json:"amount,omitempty"`
+	CountryList []string `json:"countryList,omitempty"`
+}
+
+// TransferRequest structure is generated from "#/components/schemas/TransferRequest".
+type TransferRequest struct {
+	// Format: uuid.
+	// Required.
+	FromSpaceID string `json:"fromSpaceId"`
+	// Format: uuid.
+	// Required.
+	ToSpaceID string  `json:"toSpaceId"`
+	Amount    float64 `json:"amount"` // Required.
+}
+
+// ConflictError structure is generated from "#/components/schemas/ConflictError".
+type ConflictError struct {
+	Status           int64       `json:"status,omitempty"`
+	Detail           string      `json:"detail,omitempty"`
+	Type             string      `json:"type,omitempty"`
+	UserMessage      UserMessage `json:"userMessage"` // Required.
+	Error            string      `json:"error,omitempty"`
+	ErrorDescription string      `json:"error_description,omitempty"`
+}
+
+// LockedError structure is generated from "#/components/schemas/LockedError".
+type LockedError struct {
+	Status           int64       `json:"status,omitempty"`
+	Detail           string      `json:"detail,omitempty"`
+	Type             string      `json:"type,omitempty"`
+	UserMessage      UserMessage `json:"userMessage"` // Required.
+	Error            string      `json:"error,omitempty"`
+	ErrorDescription string      `json:"error_description,omitempty"`
+}
@@ -0,0 +1,112 @@
+package n26api
+
+import (
+	"context"
+
+	"github.com/bool64/ctxd"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/limits"
+	"github.com/nhatthm/n26api/pkg/util"
+)
+
+var _ limits.Service = (*Client)(nil)
+
+// FindAllLimits finds the daily limits and the country restrictions.
+func (c *Client) FindAllLimits(ctx context.Context) ([]limits.Limit, error) {
+	res, err := c.api.GetAPISettingsAccountLimits(ctx, api.GetAPISettingsAccountLimitsRequest{})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not find limits")
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not find limits: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not find limits: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
+
+// SetLimit sets the amount of a daily limit, for example limits.LimitATMDaily.
+//
+// If N26 requires a second factor, a challenge is sent to the paired device and the update is retried until it is
+// confirmed or the MFA timeout is reached, like the login, see WithMFATimeout, WithMFAWait and WithMFAProgress.
+func (c *Client) SetLimit(ctx context.Context, limit string, amount float64) error {
+	return c.setLimit(ctx, api.LimitRequest{
+		Limit:  limit,
+		Amount: amount,
+	})
+}
+
+// SetCountryRestrictions sets the list of countries where the card can be used.
+//
+// If N26 requires a second factor, a challenge is sent to the paired device and the update is retried until it is
+// confirmed or the MFA timeout is reached, like the login, see WithMFATimeout, WithMFAWait and WithMFAProgress.
+func (c *Client) SetCountryRestrictions(ctx context.Context, countries []string) error {
+	if countries == nil {
+		countries = []string{}
+	}
+
+	return c.setLimit(ctx, api.LimitRequest{
+		Limit:       limits.LimitCountryList,
+		CountryList: countries,
+	})
+}
+
+func (c *Client) postLimit(ctx context.Context, req api.LimitRequest, mfaToken *string) (*api.PostAPISettingsAccountLimitsResponse, error) {
+	res, err := c.api.PostAPISettingsAccountLimits(ctx, api.PostAPISettingsAccountLimitsRequest{
+		DeviceToken: c.config.deviceID.String(),
+		MfaToken:    mfaToken,
+		Body:        &req,
+	})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not set limit", "limit", req.Limit)
+	}
+
+	if res.ValueBadRequest != nil {
+		return nil, ctxd.NewError(ctx, "could not set limit: invalid limit",
+			"limit", req.Limit,
+			"reason", res.ValueBadRequest.UserMessage.Detail,
+		)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not set limit: invalid token", "response", res)
+	}
+
+	return &res, nil
+}
+
+func (c *Client) setLimit(ctx context.Context, req api.LimitRequest) error {
+	res, err := c.postLimit(ctx, req, nil)
+	if err != nil {
+		return err
+	}
+
+	if res.ValueForbidden == nil {
+		return nil
+	}
+
+	mfaToken := res.ValueForbidden.MfaToken
+
+	if err := c.apiToken.challenge(ctx, mfaToken); err != nil {
+		return err
+	}
+
+	ctx = ctxd.AddFields(ctx, "limit", req.Limit)
+
+	_, err = c.apiToken.waitForMFA(ctx, "limit update", func(ctx context.Context) (bool, error) {
+		res, err := c.postLimit(ctx, req, util.StringPtr(mfaToken))
+		if err != nil {
+			return false, err
+		}
+
+		// Not confirmed yet.
+		return res.ValueForbidden == nil, nil
+	})
+
+	return err
+}
@@ -0,0 +1,255 @@
+package n26api_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.nhat.io/matcher/v2"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/limits"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func returnMFARequired(s *testkit.Server) func(*http.Request) ([]byte, error) {
+	return func(*http.Request) ([]byte, error) {
+		return json.Marshal(api.RequiredMFATokenError{
+			MfaToken: s.MFAToken().String(),
+			Error:    "mfa_required",
+			Status:   http.StatusForbidden,
+		})
+	}
+}
+
+func TestClient_FindAllLimits(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	result := []limits.Limit{
+		{Limit: limits.LimitATMDaily, Amount: 2500},
+		{Limit: limits.LimitPOSDaily, Amount: 5000},
+		{Limit: limits.LimitECommerce, Amount: 1000},
+		{Limit: limits.LimitCountryList, CountryList: []string{"DE", "FR"}},
+	}
+
+	testCases := []struct {
+		scenario       string
+		mockServer     testkit.ServerMocker
+		expectedResult []limits.Limit
+		expectedError  string
+	}{
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/settings/account/limits").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not find limits: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:       "success",
+			mockServer:     mockServer(deviceID, testkit.WithFindAllLimits(result)),
+			expectedResult: result,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.FindAllLimits(context.Background())
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_SetLimit(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockServer    testkit.ServerMocker
+		options       []n26api.Option
+		expectedError string
+	}{
+		{
+			scenario:      "invalid limit",
+			mockServer:    mockServer(deviceID, testkit.WithSetLimitFailureBadRequest(limits.LimitATMDaily, 99999)),
+			expectedError: "could not set limit: invalid limit",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPost("/api/settings/account/limits").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not set limit: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:   "success",
+			mockServer: mockServer(deviceID, testkit.WithSetLimitSuccess(limits.LimitATMDaily, 99999)),
+		},
+		{
+			scenario: "mfa challenge failure",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPost("/api/settings/account/limits").
+					ReturnCode(http.StatusForbidden).
+					Run(returnMFARequired(s))
+			}, testkit.WithAuthMFAChallengeFailure()),
+			expectedError: "failed to challenge mfa: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario: "mfa timeout",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPost("/api/settings/account/limits").
+					ReturnCode(http.StatusForbidden).
+					Run(returnMFARequired(s))
+
+				testkit.WithAuthMFAChallengeSuccess()(s)
+
+				s.ExpectPost("/api/settings/account/limits").
+					WithHeader("mfa-token", func() matcher.Matcher {
+						return matcher.Exact(s.MFAToken().String())
+					}).
+					UnlimitedTimes().
+					ReturnCode(http.StatusForbidden).
+					Run(returnMFARequired(s))
+			}),
+			options:       []n26api.Option{n26api.WithMFATimeout(50 * time.Millisecond)},
+			expectedError: "could not confirm limit update",
+		},
+		{
+			scenario:   "mfa required",
+			mockServer: mockServer(deviceID, testkit.WithSetLimitMFARequired(limits.LimitATMDaily, 99999, 2)),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID, tc.options...)
+
+			err := c.SetLimit(context.Background(), limits.LimitATMDaily, 99999)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_SetLimit_MFAWait(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	s := mockServer(deviceID, testkit.WithSetLimitMFARequired(limits.LimitATMDaily, 99999, 2))(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	logger := &ctxd.LoggerMock{}
+	progress := 0
+
+	c := newTestClient(s, deviceID,
+		n26api.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))),
+		n26api.WithLogger(logger),
+		n26api.WithMFAProgress(func(time.Duration) { progress++ }),
+	)
+
+	err := c.SetLimit(context.Background(), limits.LimitATMDaily, 99999)
+	require.NoError(t, err)
+
+	// Once for the login and after its challenge, then after the challenge and each pending poll of the limit update.
+	assert.Equal(t, 4, progress)
+
+	polls := 0
+
+	for _, span := range exporter.GetSpans() {
+		if span.Name == "n26api.mfa.poll" {
+			polls++
+		}
+	}
+
+	// One for the login, three for the limit update.
+	assert.Equal(t, 4, polls)
+	assert.Contains(t, logger.String(), "waiting for limit update confirmation")
+	assert.Contains(t, logger.String(), "polled limit update confirmation")
+}
+
+func TestClient_SetCountryRestrictions(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockServer    testkit.ServerMocker
+		countries     []string
+		expectedError string
+	}{
+		{
+			scenario:      "invalid countries",
+			mockServer:    mockServer(deviceID, testkit.WithSetCountryRestrictionsFailureBadRequest([]string{"XX"})),
+			countries:     []string{"XX"},
+			expectedError: "could not set limit: invalid limit",
+		},
+		{
+			scenario:   "no countries",
+			mockServer: mockServer(deviceID, testkit.WithSetCountryRestrictionsSuccess([]string{})),
+		},
+		{
+			scenario:   "success",
+			mockServer: mockServer(deviceID, testkit.WithSetCountryRestrictionsSuccess([]string{"DE", "FR"})),
+			countries:  []string{"DE", "FR"},
+		},
+		{
+			scenario:   "mfa required",
+			mockServer: mockServer(deviceID, testkit.WithSetCountryRestrictionsMFARequired([]string{"DE", "FR", "US"}, 0)),
+			countries:  []string{"DE", "FR", "US"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			err := c.SetCountryRestrictions(context.Background(), tc.countries)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package n26api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bool64/ctxd"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/auth"
+)
+
+// redacted replaces the secrets in the logs and in the errors.
+const redacted = "[REDACTED]"
+
+// sensitiveKeys are the normalized log keys and headers whose values are always redacted, see normalizeKey.
+var sensitiveKeys = map[string]struct{}{
+	"authorization": {},
+	"password":      {},
+	"mfatoken":      {},
+	"token":         {},
+	"accesstoken":   {},
+	"refreshtoken":  {},
+}
+
+var _ ctxd.Logger = (*redactingLogger)(nil)
+
+// WithLogger logs the lifecycle of each request and each step of the authentication at debug level. The authorization
+// headers, the passwords, the MFA tokens and the access and refresh tokens are redacted.
+func WithLogger(logger ctxd.Logger) Option {
+	return func(c *Client) {
+		c.config.logger = newRedactingLogger(logger)
+	}
+}
+
+// redactingLogger redacts the secrets in the keys and values before passing them to the logger.
+type redactingLogger struct {
+	logger ctxd.Logger
+}
+
+// Debug logs a message.
+func (l *redactingLogger) Debug(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	l.logger.Debug(ctx, msg, redactKeysAndValues(keysAndValues)...)
+}
+
+// Info logs a message.
+func (l *redactingLogger) Info(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	l.logger.Info(ctx, msg, redactKeysAndValues(keysAndValues)...)
+}
+
+// Important logs a message.
+func (l *redactingLogger) Important(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	l.logger.Important(ctx, msg, redactKeysAndValues(keysAndValues)...)
+}
+
+// Warn logs a message.
+func (l *redactingLogger) Warn(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	l.logger.Warn(ctx, msg, redactKeysAndValues(keysAndValues)...)
+}
+
+// Error logs a message.
+func (l *redactingLogger) Error(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	l.logger.Error(ctx, msg, redactKeysAndValues(keysAndValues)...)
+}
+
+// newRedactingLogger wraps the logger with the redaction, it returns nil if the logger is nil so that the logging is
+// disabled.
+func newRedactingLogger(logger ctxd.Logger) ctxd.Logger {
+	if logger == nil {
+		return nil
+	}
+
+	if _, ok := logger.(*redactingLogger); ok {
+		return logger
+	}
+
+	return &redactingLogger{logger: logger}
+}
+
+// redactKeysAndValues redacts the values of the sensitive keys and the values that are known to contain secrets.
+func redactKeysAndValues(keysAndValues []interface{}) []interface{} {
+	result := make([]interface{}, len(keysAndValues))
+
+	for i, v := range keysAndValues {
+		if i%2 == 1 {
+			if key, ok := keysAndValues[i-1].(string); ok && isSensitiveKey(key) {
+				result[i] = redacted
+
+				continue
+			}
+		}
+
+		result[i] = redact(v)
+	}
+
+	return result
+}
+
+// normalizeKey lowercases the key and removes the separators, so that "mfaToken", "mfa_token" and "MFA-Token" are the
+// same key.
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(key))
+}
+
+func isSensitiveKey(key string) bool {
+	_, ok := sensitiveKeys[normalizeKey(key)]
+
+	return ok
+}
+
+func redactString(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	return redacted
+}
+
+func redactStringPtr(s *string) *string {
+	if s == nil {
+		return nil
+	}
+
+	r := redactString(*s)
+
+	return &r
+}
+
+// redact returns a copy of the value without the secrets, the other values are returned as is.
+func redact(v interface{}) interface{} { // nolint: cyclop
+	switch v := v.(type) {
+	case auth.Token:
+		return redactString(string(v))
+
+	case auth.OAuthToken:
+		v.AccessToken = auth.Token(redactString(string(v.AccessToken)))
+		v.RefreshToken = auth.Token(redactString(string(v.RefreshToken)))
+
+		return v
+
+	case http.Header:
+		return redactHeader(v)
+
+	case api.TokenResponse:
+		return redactTokenResponse(v)
+
+	case *api.TokenResponse:
+		if v == nil {
+			return v
+		}
+
+		r := redactTokenResponse(*v)
+
+		return &r
+
+	case api.PostOauthTokenRequest:
+		return redactTokenRequest(v)
+
+	case *api.PostOauthTokenRequest:
+		if v == nil {
+			return v
+		}
+
+		r := redactTokenRequest(*v)
+
+		return &r
+
+	case api.PostOauthTokenResponse:
+		return redactTokenAPIResponse(v)
+
+	case *api.PostOauthTokenResponse:
+		if v == nil {
+			return v
+		}
+
+		r := redactTokenAPIResponse(*v)
+
+		return &r
+	}
+
+	return v
+}
+
+func redactHeader(h http.Header) http.Header {
+	r := h.Clone()
+
+	for k, values := range r {
+		if !isSensitiveKey(k) {
+			continue
+		}
+
+		for i := range values {
+			values[i] = redacted
+		}
+	}
+
+	return r
+}
+
+func redactTokenResponse(res api.TokenResponse) api.TokenResponse {
+	res.AccessToken = redactString(res.AccessToken)
+	res.RefreshToken = redactString(res.RefreshToken)
+
+	return res
+}
+
+func redactTokenRequest(req api.PostOauthTokenRequest) api.PostOauthTokenRequest {
+	req.Password = redactStringPtr(req.Password)
+	req.MfaToken = redactStringPtr(req.MfaToken)
+	req.RefreshToken = redactStringPtr(req.RefreshToken)
+
+	return req
+}
+
+func redactTokenAPIResponse(res api.PostOauthTokenResponse) api.PostOauthTokenResponse {
+	if res.ValueOK != nil {
+		r := redactTokenResponse(*res.ValueOK)
+		res.ValueOK = &r
+	}
+
+	if res.ValueForbidden != nil {
+		r := *res.ValueForbidden
+		r.MfaToken = redactString(r.MfaToken)
+		res.ValueForbidden = &r
+	}
+
+	return res
+}
+
+// loggingRoundTripper logs the lifecycle of each request, the authorization headers are redacted.
+func loggingRoundTripper(logger ctxd.Logger, tripper http.RoundTripper) RoundTripperFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		ctx := r.Context()
+		keysAndValues := []interface{}{
+			"method", r.Method,
+			"url", r.URL.Redacted(),
+		}
+
+		if op, ok := ctx.Value(ctxOperation{}).(operation); ok {
+			keysAndValues = append(keysAndValues, "pattern", op.pattern)
+		}
+
+		if page, ok := ctx.Value(ctxPage{}).(int); ok {
+			keysAndValues = append(keysAndValues, "page", page)
+		}
+
+		// The fields are shared by all the messages of the request.
+		keysAndValues = keysAndValues[:len(keysAndValues):len(keysAndValues)]
+
+		logger.Debug(ctx, "sending request", append(keysAndValues, "header", r.Header)...)
+
+		start := time.Now()
+		resp, err := tripper.RoundTrip(r)
+		duration := time.Since(start)
+
+		if err != nil {
+			logger.Debug(ctx, "request failed", append(keysAndValues, "duration", duration, "error", err)...)
+
+			return nil, err
+		}
+
+		logger.Debug(ctx, "request completed",
+			append(keysAndValues, "status_code", resp.StatusCode, "duration", duration)...,
+		)
+
+		return resp, nil
+	}
+}
@@ -0,0 +1,217 @@
+package n26api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/auth"
+	"github.com/nhatthm/n26api/pkg/device"
+	"github.com/nhatthm/n26api/pkg/testkit"
+	"github.com/nhatthm/n26api/pkg/util"
+)
+
+func TestWithLogger(t *testing.T) {
+	t.Parallel()
+
+	username := "john.doe"
+	password := "s3cr3t-p4ssw0rd"
+	deviceID := uuid.New()
+
+	s := testkit.MockServer(username, password, deviceID,
+		testkit.WithFindAllDevices([]device.Device{}),
+	)(t)
+
+	logger := &ctxd.LoggerMock{}
+
+	c := NewClient(
+		WithBaseURL(s.URL()),
+		WithDeviceID(deviceID),
+		WithCredentials(username, password),
+		WithMFAWait(5*time.Millisecond),
+		WithMFATimeout(time.Second),
+		WithLogger(logger),
+	)
+
+	_, err := c.FindAllDevices(context.Background())
+	require.NoError(t, err)
+
+	output := logger.String()
+
+	for _, secret := range []string{
+		password,
+		s.MFAToken().String(),
+		string(s.AccessToken()),
+		string(s.RefreshToken()),
+		s.BasicAuthorization(),
+	} {
+		assert.NotContains(t, output, secret)
+	}
+
+	messages := make([]string, 0, len(logger.LoggedEntries))
+
+	for _, e := range logger.LoggedEntries {
+		assert.Equal(t, "debug", e.Level)
+
+		messages = append(messages, e.Message)
+	}
+
+	expected := []string{
+		"logging in",
+		"sending request", "request completed",
+		"challenging mfa",
+		"sending request", "request completed",
+		"waiting for login confirmation",
+		"sending request", "request completed",
+		"polled login confirmation",
+		"logged in",
+		"sending request", "request completed",
+	}
+
+	assert.Equal(t, expected, messages)
+	assert.Contains(t, output, `"Authorization":["[REDACTED]"]`)
+	assert.Contains(t, output, `"mfa_token":"[REDACTED]"`)
+	assert.Contains(t, output, `"pattern":"/api/me/devices"`)
+}
+
+func TestWithLogger_Disabled(t *testing.T) {
+	t.Parallel()
+
+	c := NewClient(WithLogger(nil))
+
+	assert.Nil(t, c.config.logger)
+	assert.Nil(t, c.apiToken.logger)
+}
+
+func TestRedactKeysAndValues(t *testing.T) {
+	t.Parallel()
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer access")
+	header.Set("Device-Token", "device")
+
+	actual := redactKeysAndValues([]interface{}{
+		"username", "john.doe",
+		"password", "s3cr3t",
+		"mfaToken", "mfa",
+		"refresh_token", "refresh",
+		"Access-Token", "access",
+		"token", auth.OAuthToken{AccessToken: "access", RefreshToken: "refresh"},
+		"value", auth.Token("access"),
+		"header", header,
+		"odd",
+	})
+
+	expectedHeader := http.Header{}
+	expectedHeader.Set("Authorization", redacted)
+	expectedHeader.Set("Device-Token", "device")
+
+	expected := []interface{}{
+		"username", "john.doe",
+		"password", redacted,
+		"mfaToken", redacted,
+		"refresh_token", redacted,
+		"Access-Token", redacted,
+		"token", redacted,
+		"value", redacted,
+		"header", expectedHeader,
+		"odd",
+	}
+
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, "Bearer access", header.Get("Authorization"), "the header must not be changed")
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		value    interface{}
+		expected interface{}
+	}{
+		{
+			scenario: "empty token",
+			value:    auth.Token(""),
+			expected: "",
+		},
+		{
+			scenario: "oauth token",
+			value:    auth.OAuthToken{AccessToken: "access", RefreshToken: "refresh"},
+			expected: auth.OAuthToken{AccessToken: redacted, RefreshToken: redacted},
+		},
+		{
+			scenario: "token request",
+			value: &api.PostOauthTokenRequest{
+				GrantType: auth.GrantTypePassword,
+				Username:  util.StringPtr("john.doe"),
+				Password:  util.StringPtr("s3cr3t"),
+			},
+			expected: &api.PostOauthTokenRequest{
+				GrantType: auth.GrantTypePassword,
+				Username:  util.StringPtr("john.doe"),
+				Password:  util.StringPtr(redacted),
+			},
+		},
+		{
+			scenario: "token response",
+			value: api.PostOauthTokenResponse{
+				StatusCode: http.StatusOK,
+				ValueOK:    &api.TokenResponse{AccessToken: "access", RefreshToken: "refresh", ExpiresIn: 900},
+			},
+			expected: api.PostOauthTokenResponse{
+				StatusCode: http.StatusOK,
+				ValueOK:    &api.TokenResponse{AccessToken: redacted, RefreshToken: redacted, ExpiresIn: 900},
+			},
+		},
+		{
+			scenario: "mfa required response",
+			value: api.PostOauthTokenResponse{
+				StatusCode:     http.StatusForbidden,
+				ValueForbidden: &api.RequiredMFATokenError{MfaToken: "mfa", UserID: "user"},
+			},
+			expected: api.PostOauthTokenResponse{
+				StatusCode:     http.StatusForbidden,
+				ValueForbidden: &api.RequiredMFATokenError{MfaToken: redacted, UserID: "user"},
+			},
+		},
+		{
+			scenario: "nil response",
+			value:    (*api.TokenResponse)(nil),
+			expected: (*api.TokenResponse)(nil),
+		},
+		{
+			scenario: "other",
+			value:    42,
+			expected: 42,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, redact(tc.value))
+		})
+	}
+}
+
+func TestRedact_DoesNotChangeValue(t *testing.T) {
+	t.Parallel()
+
+	res := api.PostOauthTokenResponse{
+		ValueForbidden: &api.RequiredMFATokenError{MfaToken: "mfa"},
+	}
+
+	_ = redact(&res)
+
+	assert.Equal(t, "mfa", res.ValueForbidden.MfaToken)
+}
@@ -0,0 +1,245 @@
+package n26api
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.nhat.io/clock"
+	"golang.org/x/time/rate"
+
+	"github.com/nhatthm/n26api/pkg/auth"
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+// ErrUserNotFound indicates that the user is not added to the Manager.
+var ErrUserNotFound = errors.New("user not found")
+
+// deviceIDNamespace is the namespace of the device IDs generated from the usernames.
+var deviceIDNamespace = uuid.MustParse("5b3e9a8c-7d0f-4b6e-9a51-2f4c8d1e6a73")
+
+// ManagerOption configures Manager.
+type ManagerOption func(m *Manager)
+
+// session is a user of the Manager, the client is created on the first use.
+type session struct {
+	password string
+	options  []Option
+
+	client   *Client
+	lastUsed time.Time
+}
+
+// Manager manages the clients of several N26 users. The clients share the HTTP transport, the token storage and the
+// rate limiter. A client is created on the first use, and the user logs in on the first request.
+type Manager struct {
+	options   []Option
+	transport http.RoundTripper
+	storage   auth.TokenStorage
+	limiter   *rate.Limiter
+	deviceID  func(username string) uuid.UUID
+	clock     clock.Clock
+
+	sessions map[string]*session
+
+	mu sync.Mutex
+}
+
+// WithManagerClientOptions sets the options of all the clients, for example WithBaseURL.
+func WithManagerClientOptions(options ...Option) ManagerOption {
+	return func(m *Manager) {
+		m.options = append(m.options, options...)
+	}
+}
+
+// WithManagerTransport sets the HTTP transport shared by all the clients.
+func WithManagerTransport(transport http.RoundTripper) ManagerOption {
+	return func(m *Manager) {
+		m.transport = transport
+	}
+}
+
+// WithManagerTokenStorage sets the token storage shared by all the clients. The tokens are stored by username and
+// device ID.
+func WithManagerTokenStorage(storage auth.TokenStorage) ManagerOption {
+	return func(m *Manager) {
+		m.storage = storage
+	}
+}
+
+// WithManagerRateLimit limits the number of requests of all the clients, see rate.NewLimiter. The limiter also applies
+// to the clients that have their own transport, see WithTransport.
+func WithManagerRateLimit(limit rate.Limit, burst int) ManagerOption {
+	return func(m *Manager) {
+		m.limiter = rate.NewLimiter(limit, burst)
+	}
+}
+
+// WithManagerDeviceID sets the function that provides the device ID of a user. By default, the device ID is generated
+// from the username, so it is the same after a restart and the user does not have to pair a new device.
+func WithManagerDeviceID(deviceID func(username string) uuid.UUID) ManagerOption {
+	return func(m *Manager) {
+		m.deviceID = deviceID
+	}
+}
+
+// WithManagerClock sets the clock (for testing purpose).
+func WithManagerClock(clock clock.Clock) ManagerOption {
+	return func(m *Manager) {
+		m.clock = clock
+	}
+}
+
+// AddUser adds a user or replaces its password and options, the current client of the user is evicted. The options
+// are applied after the options of the manager. If the password is empty, the username and the password are provided
+// by the credentials providers of the options, see WithCredentialsProvider. The credentials in the environment are
+// never used by the clients of the manager.
+func (m *Manager) AddUser(username, password string, options ...Option) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sessions[username] = &session{
+		password: password,
+		options:  options,
+	}
+}
+
+// RemoveUser removes a user and its client.
+func (m *Manager) RemoveUser(username string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, username)
+}
+
+// Users returns the usernames of all the users.
+func (m *Manager) Users() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	users := make([]string, 0, len(m.sessions))
+
+	for username := range m.sessions {
+		users = append(users, username)
+	}
+
+	sort.Strings(users)
+
+	return users
+}
+
+// Client returns the client of a user, it is created if it does not exist.
+func (m *Manager) Client(username string) (*Client, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.sessions[username]
+	if !ok {
+		return nil, ErrUserNotFound
+	}
+
+	if s.client == nil {
+		s.client = m.newClient(username, s)
+	}
+
+	s.lastUsed = m.clock.Now()
+
+	return s.client, nil
+}
+
+// TransactionFinder returns the transaction.Finder of a user.
+func (m *Manager) TransactionFinder(username string) (transaction.Finder, error) {
+	c, err := m.Client(username)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Evict removes the client of a user, a new one is created on the next use. The token is kept in the token storage.
+func (m *Manager) Evict(username string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if s, ok := m.sessions[username]; ok {
+		s.client = nil
+	}
+}
+
+// EvictIdle removes the clients that are not used for the given duration, and returns the usernames of them.
+func (m *Manager) EvictIdle(idle time.Duration) []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.clock.Now()
+	evicted := make([]string, 0)
+
+	for username, s := range m.sessions {
+		if s.client != nil && now.Sub(s.lastUsed) >= idle {
+			s.client = nil
+
+			evicted = append(evicted, username)
+		}
+	}
+
+	sort.Strings(evicted)
+
+	return evicted
+}
+
+func (m *Manager) newClient(username string, s *session) *Client {
+	options := make([]Option, 0, len(m.options)+len(s.options)+7)
+	options = append(options,
+		withoutDefaultCredentialsProviders(),
+		WithTransport(m.transport),
+		WithTokenStorage(m.storage),
+		WithDeviceID(m.deviceID(username)),
+		WithClock(m.clock),
+	)
+	options = append(options, m.options...)
+	options = append(options, s.options...)
+	options = append(options, WithCredentials(username, s.password), withRateLimit(m.limiter))
+
+	return NewClient(options...)
+}
+
+// withRateLimit wraps the transport of the client with the shared rate limiter. It is the last option, so that a
+// WithTransport of the manager or of a user does not bypass the limiter.
+func withRateLimit(limiter *rate.Limiter) Option {
+	return func(c *Client) {
+		if limiter != nil {
+			c.config.transport = RateLimitRoundTripper(limiter, c.config.transport)
+		}
+	}
+}
+
+// withoutDefaultCredentialsProviders removes the default credentials providers of the client, so that the credentials
+// of a user are not taken from the environment.
+func withoutDefaultCredentialsProviders() Option {
+	return func(c *Client) {
+		c.config.credentials = newChainCredentialsProvider()
+	}
+}
+
+// NewManager initiates a new Manager.
+func NewManager(options ...ManagerOption) *Manager {
+	m := &Manager{
+		transport: http.DefaultTransport,
+		storage:   NewInMemoryTokenStorage(),
+		deviceID: func(username string) uuid.UUID {
+			return uuid.NewSHA1(deviceIDNamespace, []byte(username))
+		},
+		clock:    clock.New(),
+		sessions: make(map[string]*session),
+	}
+
+	for _, o := range options {
+		o(m)
+	}
+
+	return m
+}
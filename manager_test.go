@@ -0,0 +1,340 @@
+package n26api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/device"
+	"github.com/nhatthm/n26api/pkg/testkit"
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+type fakeClock struct {
+	now time.Time
+
+	mu sync.Mutex
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+}
+
+func newManager(s *testkit.Server, deviceIDs map[string]uuid.UUID, options ...n26api.ManagerOption) *n26api.Manager {
+	return n26api.NewManager(append([]n26api.ManagerOption{
+		n26api.WithManagerClientOptions(
+			n26api.WithBaseURL(s.URL()),
+			n26api.WithMFAWait(5*time.Millisecond),
+			n26api.WithMFATimeout(time.Second),
+		),
+		n26api.WithManagerDeviceID(func(username string) uuid.UUID {
+			return deviceIDs[username]
+		}),
+	}, options...)...)
+}
+
+func TestManager_UserNotFound(t *testing.T) {
+	t.Parallel()
+
+	m := n26api.NewManager()
+
+	c, err := m.Client("john.doe")
+
+	assert.Nil(t, c)
+	assert.True(t, errors.Is(err, n26api.ErrUserNotFound))
+
+	f, err := m.TransactionFinder("john.doe")
+
+	assert.True(t, f == nil, "the finder must be a nil interface")
+	assert.True(t, errors.Is(err, n26api.ErrUserNotFound))
+}
+
+func TestManager_Users(t *testing.T) {
+	t.Parallel()
+
+	m := n26api.NewManager()
+
+	m.AddUser("john.doe", "123456")
+	m.AddUser("jane.doe", "654321")
+
+	assert.Equal(t, []string{"jane.doe", "john.doe"}, m.Users())
+
+	m.RemoveUser("john.doe")
+
+	assert.Equal(t, []string{"jane.doe"}, m.Users())
+
+	_, err := m.Client("john.doe")
+
+	assert.True(t, errors.Is(err, n26api.ErrUserNotFound))
+}
+
+func TestManager_DefaultDeviceID(t *testing.T) {
+	t.Parallel()
+
+	m1 := n26api.NewManager()
+	m2 := n26api.NewManager()
+
+	for _, m := range []*n26api.Manager{m1, m2} {
+		m.AddUser("john.doe", "123456")
+		m.AddUser("jane.doe", "654321")
+	}
+
+	john1, err := m1.Client("john.doe")
+	require.NoError(t, err)
+
+	john2, err := m2.Client("john.doe")
+	require.NoError(t, err)
+
+	jane, err := m1.Client("jane.doe")
+	require.NoError(t, err)
+
+	assert.Equal(t, john1.DeviceID(), john2.DeviceID())
+	assert.NotEqual(t, john1.DeviceID(), jane.DeviceID())
+	assert.NotEqual(t, uuid.UUID{}, jane.DeviceID())
+}
+
+func TestManager_Sessions(t *testing.T) {
+	t.Parallel()
+
+	deviceIDs := map[string]uuid.UUID{
+		"john.doe": uuid.New(),
+		"jane.doe": uuid.New(),
+	}
+
+	johnDevices := []device.Device{{DeviceToken: deviceIDs["john.doe"], Name: "john"}}
+	janeDevices := []device.Device{{DeviceToken: deviceIDs["jane.doe"], Name: "jane"}}
+
+	// The users log in on the first request, the tokens are reused even after the eviction.
+	john := testkit.MockServer("john.doe", "123456", deviceIDs["john.doe"],
+		testkit.WithFindAllDevices(johnDevices),
+		testkit.WithFindAllDevices(johnDevices),
+	)(t)
+
+	jane := testkit.MockServer("jane.doe", "654321", deviceIDs["jane.doe"],
+		testkit.WithFindAllDevices(janeDevices),
+		testkit.WithFindAllDevices(janeDevices),
+	)(t)
+
+	m := newManager(john, deviceIDs)
+
+	m.AddUser("john.doe", "123456")
+	m.AddUser("jane.doe", "654321", n26api.WithBaseURL(jane.URL()))
+
+	findDevices := func(username string) []device.Device {
+		c, err := m.Client(username)
+		require.NoError(t, err)
+
+		result, err := c.FindAllDevices(context.Background())
+		require.NoError(t, err)
+
+		return result
+	}
+
+	assert.Equal(t, johnDevices, findDevices("john.doe"))
+	assert.Equal(t, janeDevices, findDevices("jane.doe"))
+
+	c, err := m.Client("john.doe")
+	require.NoError(t, err)
+
+	m.Evict("john.doe")
+
+	evicted, err := m.Client("john.doe")
+	require.NoError(t, err)
+
+	assert.NotSame(t, c, evicted)
+
+	assert.Equal(t, johnDevices, findDevices("john.doe"))
+	assert.Equal(t, janeDevices, findDevices("jane.doe"))
+}
+
+func TestManager_TransactionFinder(t *testing.T) {
+	t.Parallel()
+
+	deviceIDs := map[string]uuid.UUID{"john.doe": uuid.New()}
+	id := uuid.New()
+	from := time.Now()
+	to := from.Add(time.Hour)
+
+	s := testkit.MockServer("john.doe", "123456", deviceIDs["john.doe"],
+		testkit.WithFindAllTransactionsInRange(from, to, n26api.DefaultPageSize, []transaction.Transaction{{ID: id}}),
+	)(t)
+
+	m := newManager(s, deviceIDs)
+
+	m.AddUser("john.doe", "123456")
+
+	f, err := m.TransactionFinder("john.doe")
+	require.NoError(t, err)
+
+	result, err := f.FindAllTransactionsInRange(context.Background(), from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, []transaction.Transaction{{ID: id}}, result)
+}
+
+func TestManager_NoEnvCredentials(t *testing.T) {
+	t.Setenv("N26_USERNAME", "env-username")
+	t.Setenv("N26_PASSWORD", "env-password")
+
+	m := n26api.NewManager(n26api.WithManagerClientOptions(n26api.WithBaseURL("http://localhost")))
+
+	m.AddUser("john.doe", "")
+
+	c, err := m.Client("john.doe")
+	require.NoError(t, err)
+
+	_, err = c.FindAllDevices(context.Background())
+
+	assert.ErrorIs(t, err, n26api.ErrPasswordIsEmpty)
+}
+
+func TestManager_EvictIdle(t *testing.T) {
+	t.Parallel()
+
+	c := &fakeClock{now: time.Now()}
+	m := n26api.NewManager(n26api.WithManagerClock(c))
+
+	m.AddUser("john.doe", "123456")
+	m.AddUser("jane.doe", "654321")
+	m.AddUser("jim.doe", "000000")
+
+	john, err := m.Client("john.doe")
+	require.NoError(t, err)
+
+	c.Add(10 * time.Minute)
+
+	_, err = m.Client("jane.doe")
+	require.NoError(t, err)
+
+	c.Add(5 * time.Minute)
+
+	assert.Equal(t, []string{"john.doe"}, m.EvictIdle(10*time.Minute))
+	assert.Empty(t, m.EvictIdle(10*time.Minute))
+
+	newJohn, err := m.Client("john.doe")
+	require.NoError(t, err)
+
+	assert.NotSame(t, john, newJohn)
+}
+
+func TestManager_SharedTransport(t *testing.T) {
+	t.Parallel()
+
+	deviceIDs := map[string]uuid.UUID{
+		"john.doe": uuid.New(),
+		"jane.doe": uuid.New(),
+	}
+
+	john := testkit.MockServer("john.doe", "123456", deviceIDs["john.doe"], testkit.WithFindAllDevices([]device.Device{}))(t)
+	jane := testkit.MockServer("jane.doe", "654321", deviceIDs["jane.doe"], testkit.WithFindAllDevices([]device.Device{}))(t)
+
+	var (
+		requests int
+		mu       sync.Mutex
+	)
+
+	transport := n26api.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	m := newManager(john, deviceIDs,
+		n26api.WithManagerTransport(transport),
+		n26api.WithManagerRateLimit(rate.Inf, 1),
+	)
+
+	m.AddUser("john.doe", "123456")
+	m.AddUser("jane.doe", "654321", n26api.WithBaseURL(jane.URL()))
+
+	for _, username := range []string{"john.doe", "jane.doe"} {
+		c, err := m.Client(username)
+		require.NoError(t, err)
+
+		_, err = c.FindAllDevices(context.Background())
+		require.NoError(t, err)
+	}
+
+	// Login, MFA challenge, login confirmation and the request, for each user.
+	assert.Equal(t, 8, requests)
+}
+
+func TestManager_RateLimitCustomTransport(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+
+	transport := n26api.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		atomic.AddInt32(&requests, 1)
+
+		return nil, errors.New("unexpected request")
+	})
+
+	m := n26api.NewManager(
+		n26api.WithManagerClientOptions(n26api.WithBaseURL("http://localhost")),
+		n26api.WithManagerRateLimit(rate.Every(time.Hour), 0),
+	)
+
+	m.AddUser("john.doe", "123456", n26api.WithTransport(transport))
+
+	c, err := m.Client("john.doe")
+	require.NoError(t, err)
+
+	_, err = c.FindAllDevices(context.Background())
+
+	assert.ErrorContains(t, err, "exceeds limiter's burst")
+	assert.Zero(t, atomic.LoadInt32(&requests))
+}
+
+func TestRateLimitRoundTripper(t *testing.T) {
+	t.Parallel()
+
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	next := n26api.RoundTripperFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusNoContent}, nil
+	})
+
+	rt := n26api.RateLimitRoundTripper(limiter, next)
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "/", nil)
+	require.NoError(t, err)
+
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	// The next request has to wait for an hour.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/", nil)
+	require.NoError(t, err)
+
+	resp, err = rt.RoundTrip(req) // nolint:bodyclose
+
+	assert.Nil(t, resp)
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package n26api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/nhatthm/n26api/pkg/metrics"
+)
+
+// operationFindAllTransactionsInRange is the name of the paginated operation in the metrics.
+const operationFindAllTransactionsInRange = "FindAllTransactionsInRange"
+
+// WithMetrics observes the requests, the logins, the refreshes, the MFA waits and the paginations, for example with
+// the Prometheus or the expvar adapter in pkg/metrics.
+func WithMetrics(m metrics.Metrics) Option {
+	return func(c *Client) {
+		if m == nil {
+			m = metrics.Discard()
+		}
+
+		c.config.metrics = m
+	}
+}
+
+// metricsRoundTripper observes the duration and the status code of each request.
+func metricsRoundTripper(m metrics.Metrics, tripper http.RoundTripper) RoundTripperFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		pattern := "unknown"
+
+		if op, ok := r.Context().Value(ctxOperation{}).(operation); ok {
+			pattern = op.pattern
+		}
+
+		start := time.Now()
+		resp, err := tripper.RoundTrip(r)
+		duration := time.Since(start)
+
+		if err != nil {
+			m.ObserveRequest(r.Method, pattern, 0, duration)
+
+			return nil, err
+		}
+
+		m.ObserveRequest(r.Method, pattern, resp.StatusCode, duration)
+
+		return resp, nil
+	}
+}
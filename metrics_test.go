@@ -0,0 +1,245 @@
+package n26api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/device"
+	"github.com/nhatthm/n26api/pkg/metrics"
+	"github.com/nhatthm/n26api/pkg/testkit"
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+var _ metrics.Metrics = (*recordedMetrics)(nil)
+
+type recordedMetrics struct {
+	requests  []string
+	logins    []string
+	refreshes []string
+	mfaWaits  []string
+	mfaTimes  []time.Duration
+	pages     []string
+
+	mu sync.Mutex
+}
+
+func (m *recordedMetrics) ObserveRequest(method, pattern string, statusCode int, _ time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests = append(m.requests, fmt.Sprintf("%s %s %d", method, pattern, statusCode))
+}
+
+func (m *recordedMetrics) ObserveLogin(outcome string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.logins = append(m.logins, outcome)
+}
+
+func (m *recordedMetrics) ObserveRefresh(outcome string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.refreshes = append(m.refreshes, outcome)
+}
+
+func (m *recordedMetrics) ObserveMFAWait(outcome string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mfaWaits = append(m.mfaWaits, outcome)
+	m.mfaTimes = append(m.mfaTimes, duration)
+}
+
+func (m *recordedMetrics) ObservePages(operation string, pages int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pages = append(m.pages, fmt.Sprintf("%s %d", operation, pages))
+}
+
+func TestWithMetrics(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	from := time.Now()
+	to := from.Add(time.Hour)
+	transactions := []transaction.Transaction{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	s := testkit.MockEmptyServer(
+		testkit.WithAuthPasswordLoginSuccess(n26Username, n26Password, deviceID),
+		testkit.WithAuthMFAChallengeSuccess(),
+		testkit.WithAuthConfirmLoginFailureInvalidToken(1),
+		testkit.WithAuthConfirmLoginSuccess(),
+		testkit.WithFindAllTransactionsInRange(from, to, 1, transactions),
+		testkit.WithAuthRefreshTokenSuccess(),
+		testkit.WithFindAllDevices([]device.Device{}),
+	)(t)
+
+	m := &recordedMetrics{}
+	clock := &fakeClock{now: time.Now()}
+
+	c := n26api.NewClient(
+		n26api.WithBaseURL(s.URL()),
+		n26api.WithDeviceID(deviceID),
+		n26api.WithCredentials(n26Username, n26Password),
+		n26api.WithMFAWait(5*time.Millisecond),
+		n26api.WithMFATimeout(time.Second),
+		n26api.WithTransactionsPageSize(1),
+		n26api.WithClock(clock),
+		n26api.WithMetrics(m),
+	)
+
+	result, err := c.FindAllTransactionsInRange(context.Background(), from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, transactions, result)
+
+	// The access token is expired, it is refreshed.
+	clock.Add(20 * time.Minute)
+
+	_, err = c.FindAllDevices(context.Background())
+	require.NoError(t, err)
+
+	expectedRequests := []string{
+		fmt.Sprintf("POST /oauth/token %d", http.StatusForbidden),
+		fmt.Sprintf("POST /api/mfa/challenge %d", http.StatusCreated),
+		fmt.Sprintf("POST /oauth/token %d", http.StatusUnauthorized),
+		fmt.Sprintf("POST /oauth/token %d", http.StatusOK),
+		fmt.Sprintf("GET /api/smrt/transactions %d", http.StatusOK),
+		fmt.Sprintf("GET /api/smrt/transactions %d", http.StatusOK),
+		fmt.Sprintf("GET /api/smrt/transactions %d", http.StatusOK),
+		fmt.Sprintf("POST /oauth/token %d", http.StatusOK),
+		fmt.Sprintf("GET /api/me/devices %d", http.StatusOK),
+	}
+
+	assert.Equal(t, expectedRequests, m.requests)
+	assert.Equal(t, []string{metrics.OutcomeSuccess}, m.logins)
+	assert.Equal(t, []string{metrics.OutcomeConfirmed}, m.mfaWaits)
+	assert.Equal(t, []string{metrics.OutcomeSuccess}, m.refreshes)
+	assert.Equal(t, []string{"FindAllTransactionsInRange 3"}, m.pages)
+}
+
+func TestWithMetrics_MFAWaitDuration(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+
+	s := testkit.MockEmptyServer(
+		testkit.WithAuthPasswordLoginSuccess(n26Username, n26Password, deviceID),
+		testkit.WithAuthMFAChallengeSuccess(),
+		testkit.WithAuthConfirmLoginFailureInvalidToken(1),
+		testkit.WithAuthConfirmLoginSuccess(),
+		testkit.WithFindAllDevices([]device.Device{}),
+	)(t)
+
+	m := &recordedMetrics{}
+	clock := &fakeClock{now: time.Now()}
+
+	var remaining []time.Duration
+
+	c := n26api.NewClient(
+		n26api.WithBaseURL(s.URL()),
+		n26api.WithDeviceID(deviceID),
+		n26api.WithCredentials(n26Username, n26Password),
+		n26api.WithMFAWait(5*time.Millisecond),
+		n26api.WithMFATimeout(time.Minute),
+		n26api.WithClock(clock),
+		n26api.WithMetrics(m),
+		n26api.WithMFAProgress(func(d time.Duration) {
+			remaining = append(remaining, d)
+
+			// Every poll takes 10 seconds.
+			clock.Add(10 * time.Second)
+		}),
+	)
+
+	_, err := c.FindAllDevices(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []time.Duration{time.Minute, 50 * time.Second}, remaining)
+	assert.Equal(t, []string{metrics.OutcomeConfirmed}, m.mfaWaits)
+	assert.Equal(t, []time.Duration{20 * time.Second}, m.mfaTimes)
+}
+
+func TestWithMetrics_LoginFailure(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario         string
+		mockServer       func(deviceID uuid.UUID) testkit.ServerMocker
+		expectedOutcome  string
+		expectedMFAWaits []string
+	}{
+		{
+			scenario: "wrong credentials",
+			mockServer: func(deviceID uuid.UUID) testkit.ServerMocker {
+				return testkit.MockEmptyServer(
+					testkit.WithAuthPasswordLoginFailureWrongCredentials(n26Username, n26Password, deviceID),
+				)
+			},
+			expectedOutcome: metrics.OutcomeWrongCredentials,
+		},
+		{
+			scenario: "too many attempts",
+			mockServer: func(deviceID uuid.UUID) testkit.ServerMocker {
+				return testkit.MockEmptyServer(
+					testkit.WithAuthPasswordLoginFailureTooManyAttempts(n26Username, n26Password, deviceID),
+				)
+			},
+			expectedOutcome: metrics.OutcomeTooManyAttempts,
+		},
+		{
+			scenario: "mfa timeout",
+			mockServer: func(deviceID uuid.UUID) testkit.ServerMocker {
+				return testkit.MockEmptyServer(
+					testkit.WithAuthPasswordLoginSuccess(n26Username, n26Password, deviceID),
+					testkit.WithAuthMFAChallengeSuccess(),
+					func(s *testkit.Server) {
+						s.ExpectWithBasicAuth(http.MethodPost, "/oauth/token").
+							ReturnCode(http.StatusUnauthorized).
+							UnlimitedTimes()
+					},
+				)
+			},
+			expectedOutcome:  metrics.OutcomeTimeout,
+			expectedMFAWaits: []string{metrics.OutcomeTimeout},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			deviceID := uuid.New()
+			s := tc.mockServer(deviceID)(t)
+			m := &recordedMetrics{}
+
+			c := n26api.NewClient(
+				n26api.WithBaseURL(s.URL()),
+				n26api.WithDeviceID(deviceID),
+				n26api.WithCredentials(n26Username, n26Password),
+				n26api.WithMFAWait(5*time.Millisecond),
+				n26api.WithMFATimeout(50*time.Millisecond),
+				n26api.WithMetrics(m),
+			)
+
+			_, err := c.FindAllDevices(context.Background())
+			require.Error(t, err)
+
+			assert.Equal(t, []string{tc.expectedOutcome}, m.logins)
+			assert.Equal(t, tc.expectedMFAWaits, m.mfaWaits)
+		})
+	}
+}
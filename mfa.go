@@ -0,0 +1,85 @@
+package n26api
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nhatthm/n26api/pkg/metrics"
+)
+
+// errNotConfirmed indicates that an action is not confirmed on the paired device yet, the wait goes on.
+var errNotConfirmed = errors.New("not confirmed")
+
+// mfaConfirmFunc checks once whether an action is confirmed on the paired device. An error stops the wait, unless it is
+// errNotConfirmed.
+type mfaConfirmFunc func(ctx context.Context) (confirmed bool, err error)
+
+// waitForMFA polls until an action, for example "login", is confirmed on the paired device, or the MFA timeout. It
+// returns the outcome of the wait for the metrics.
+func (p *apiTokenProvider) waitForMFA(ctx context.Context, action string, confirm mfaConfirmFunc) (outcome string, err error) {
+	ctx, span := startSpan(ctx, p.tracer, "n26api.mfa.wait")
+	defer func() { endSpan(span, err) }()
+
+	// The clock measures the wait, so that the metrics and the progress agree. The timeout is in real time because the
+	// clock has no timer.
+	start := p.clock.Now()
+	deadline := start.Add(p.mfaTimeout)
+
+	defer func() { p.metrics.ObserveMFAWait(outcome, p.clock.Now().Sub(start)) }()
+
+	timeout, cancel := context.WithTimeout(ctx, p.mfaTimeout)
+	defer cancel()
+
+	p.reportMFAProgress(deadline)
+	p.debug(ctx, "waiting for "+action+" confirmation", "timeout", p.mfaTimeout, "wait", p.mfaWait)
+
+	ticker := time.NewTicker(p.mfaWait)
+	defer ticker.Stop()
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ticker.C:
+			confirmed, err := p.pollMFA(timeout, action, attempt, confirm)
+
+			switch {
+			case confirmed:
+				return metrics.OutcomeConfirmed, nil
+
+			case err != nil && timeout.Err() != nil:
+				return metrics.OutcomeTimeout, ctxd.NewError(ctx, "could not confirm "+action, "reason", "timeout")
+
+			case err != nil && !errors.Is(err, errNotConfirmed):
+				return metrics.OutcomeError, err
+			}
+
+			p.reportMFAProgress(deadline)
+
+		case <-timeout.Done():
+			return metrics.OutcomeTimeout, ctxd.NewError(ctx, "could not confirm "+action, "reason", "timeout")
+		}
+	}
+}
+
+// pollMFA checks once whether an action is confirmed.
+func (p *apiTokenProvider) pollMFA(ctx context.Context, action string, attempt int, confirm mfaConfirmFunc) (bool, error) {
+	ctx, span := startSpan(ctx, p.tracer, "n26api.mfa.poll", trace.WithAttributes(attrMFAPoll.Int(attempt)))
+	defer span.End()
+
+	confirmed, err := confirm(ctx)
+
+	span.SetAttributes(attrConfirmed.Bool(confirmed))
+	p.debug(ctx, "polled "+action+" confirmation", "attempt", attempt, "confirmed", confirmed, "error", err)
+
+	return confirmed, err
+}
+
+// reportMFAProgress reports the remaining time until the deadline of the wait.
+func (p *apiTokenProvider) reportMFAProgress(deadline time.Time) {
+	if p.mfaProgress != nil {
+		p.mfaProgress(deadline.Sub(p.clock.Now()))
+	}
+}
@@ -0,0 +1,32 @@
+package n26api
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+// MFAProgressFunc is called while waiting for the login or a limit update to be confirmed on the paired device, right
+// after the challenge and after every unconfirmed attempt, with the time left before the MFA timeout.
+type MFAProgressFunc func(remaining time.Duration)
+
+// WithMFAProgress sets a function to report the progress of the login and limit update confirmations, see
+// MFAProgressPrinter.
+func WithMFAProgress(progress MFAProgressFunc) Option {
+	return func(c *Client) {
+		c.config.mfaProgress = progress
+	}
+}
+
+// MFAProgressPrinter prints the progress of the login confirmation, for example:
+//
+//	approve the login on your phone… 12s left
+func MFAProgressPrinter(w io.Writer) MFAProgressFunc {
+	return func(remaining time.Duration) {
+		if remaining < 0 {
+			remaining = 0
+		}
+
+		_, _ = fmt.Fprintf(w, "approve the login on your phone… %s left\n", remaining.Round(time.Second))
+	}
+}
@@ -1,6 +1,7 @@
 package n26api
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/google/uuid"
@@ -66,6 +67,20 @@ func WithCredentialsProviderAtLast(provider CredentialsProvider) Option {
 	}
 }
 
+// WithCredentialsProviderV2 chains a new credentials provider that can use the context and report errors.
+func WithCredentialsProviderV2(provider CredentialsProviderV2) Option {
+	return func(c *Client) {
+		c.config.credentials.prepend(downgradeCredentialsProvider(provider))
+	}
+}
+
+// WithTransport sets the HTTP transport of the API requests, including the authentication.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(c *Client) {
+		c.config.transport = transport
+	}
+}
+
 // WithTokenProvider chains a new token provider.
 func WithTokenProvider(provider auth.TokenProvider) Option {
 	return func(c *Client) {
@@ -73,6 +88,14 @@ func WithTokenProvider(provider auth.TokenProvider) Option {
 	}
 }
 
+// WithTokenChainPolicy sets the policy of the chain of token providers when a provider fails, see ChainPolicy. By
+// default, the chain stops at the first error, so a failing provider prevents the login with the credentials.
+func WithTokenChainPolicy(policy ChainPolicy) Option {
+	return func(c *Client) {
+		c.token.policy = policy
+	}
+}
+
 // WithTokenStorage sets token storage for the internal apiTokenProvider.
 func WithTokenStorage(storage auth.TokenStorage) Option {
 	return func(c *Client) {
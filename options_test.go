@@ -2,6 +2,7 @@ package n26api
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -128,7 +129,19 @@ func TestWithTokenProvider(t *testing.T) {
 
 	expected := chainTokenProviders(provider2, provider1)
 
-	assert.Equal(t, *expected, (*c.token)[0:len(*c.token)-1])
+	assert.Equal(t, expected.providers, c.token.providers[0:len(c.token.providers)-1])
+}
+
+func TestWithTokenChainPolicy(t *testing.T) {
+	t.Parallel()
+
+	c := NewClient()
+
+	assert.Nil(t, c.token.policy)
+
+	c = NewClient(WithTokenChainPolicy(ChainSkipOnError))
+
+	assert.True(t, c.token.policy(errors.New("failed to get token")))
 }
 
 func TestWithTokenStorage(t *testing.T) {
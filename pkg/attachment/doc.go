@@ -0,0 +1,2 @@
+// Package attachment provides contracts for N26 Transaction Attachment APIs.
+package attachment
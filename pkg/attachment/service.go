@@ -0,0 +1,24 @@
+package attachment
+
+import (
+	"context"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// Finder is a service to find n26 transaction attachments.
+type Finder interface {
+	// FindAllAttachments finds all attachments of a transaction.
+	FindAllAttachments(ctx context.Context, transactionID uuid.UUID) ([]Attachment, error)
+}
+
+// Service is a service to manage n26 transaction attachments.
+type Service interface {
+	Finder
+
+	// UploadAttachment uploads an image or a PDF document read from r to a transaction.
+	UploadAttachment(ctx context.Context, transactionID uuid.UUID, fileName string, r io.Reader) (*Attachment, error)
+	// DownloadAttachment downloads an attachment of a transaction and writes its content to w.
+	DownloadAttachment(ctx context.Context, transactionID uuid.UUID, attachmentID uuid.UUID, w io.Writer) error
+}
@@ -0,0 +1,2 @@
+// Package database provides an auth.TokenStorage backed by a database/sql database, for example Postgres or SQLite.
+package database
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+type migration struct {
+	version    int
+	name       string
+	statements []string
+}
+
+// Migrate creates or upgrades the tables of the token storage. The applied migrations are recorded in the
+// n26api_schema_migrations table, so it is safe to call Migrate on every start.
+//
+// Migrate does not take a lock, several replicas may start and apply a migration at the same time: the migrations
+// are idempotent and only one replica records a version. When a migration fails because another replica has just
+// applied it, for example on the unique version of n26api_schema_migrations, the migration is skipped.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	all, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+
+	if _, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS n26api_schema_migrations (
+    version    INTEGER NOT NULL PRIMARY KEY,
+    applied_at BIGINT  NOT NULL
+)`); err != nil {
+		return fmt.Errorf("could not create schema migrations table: %w", err)
+	}
+
+	for _, m := range all {
+		applied, err := isApplied(ctx, db, m.version)
+		if err != nil {
+			return fmt.Errorf("could not check migration %s: %w", m.name, err)
+		}
+
+		if applied {
+			continue
+		}
+
+		if err := applyOnce(ctx, db, m); err != nil {
+			return fmt.Errorf("could not apply migration %s: %w", m.name, err)
+		}
+	}
+
+	return nil
+}
+
+// applyOnce applies a migration, the failure is ignored if the migration has been applied by someone else meanwhile.
+func applyOnce(ctx context.Context, db *sql.DB, m migration) error {
+	err := apply(ctx, db, m)
+	if err == nil {
+		return nil
+	}
+
+	if applied, checkErr := isApplied(ctx, db, m.version); checkErr == nil && applied {
+		return nil
+	}
+
+	return err
+}
+
+func isApplied(ctx context.Context, db *sql.DB, version int) (bool, error) {
+	var applied int
+
+	if err := db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM n26api_schema_migrations WHERE version = $1`, version,
+	).Scan(&applied); err != nil {
+		return false, err
+	}
+
+	return applied > 0, nil
+}
+
+func apply(ctx context.Context, db *sql.DB, m migration) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback() // nolint:errcheck
+		}
+	}()
+
+	for _, stmt := range m.statements {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO n26api_schema_migrations (version, applied_at) VALUES ($1, $2)`, m.version, time.Now().UnixNano(),
+	); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// loadMigrations loads the embedded migrations, ordered by the version prefix of the file names.
+func loadMigrations() ([]migration, error) {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return nil, fmt.Errorf("could not read migrations: %w", err)
+	}
+
+	result := make([]migration, 0, len(entries))
+
+	for _, e := range entries {
+		prefix, _, _ := strings.Cut(e.Name(), "_")
+
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse migration version %s: %w", e.Name(), err)
+		}
+
+		data, err := migrations.ReadFile(path.Join("migrations", e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("could not read migration %s: %w", e.Name(), err)
+		}
+
+		result = append(result, migration{
+			version:    version,
+			name:       e.Name(),
+			statements: splitStatements(string(data)),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].version < result[j].version
+	})
+
+	return result, nil
+}
+
+// splitStatements splits a migration into statements because not all the drivers run several statements at once.
+func splitStatements(script string) []string {
+	parts := strings.Split(script, ";")
+	result := make([]string, 0, len(parts))
+
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			result = append(result, p)
+		}
+	}
+
+	return result
+}
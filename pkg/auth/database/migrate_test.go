@@ -0,0 +1,61 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite"
+)
+
+func TestApplyOnce_AppliedBySomeoneElse(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tokens.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = db.Close() // nolint:errcheck
+	})
+
+	require.NoError(t, Migrate(context.Background(), db))
+
+	all, err := loadMigrations()
+	require.NoError(t, err)
+
+	// Another replica has recorded the version after the check, so the insert fails on the unique version.
+	err = apply(context.Background(), db, all[0])
+	require.Error(t, err)
+
+	require.NoError(t, applyOnce(context.Background(), db, all[0]))
+
+	var versions int
+
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM n26api_schema_migrations`).Scan(&versions))
+
+	assert.Equal(t, 1, versions)
+}
+
+func TestApplyOnce_Failure(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tokens.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = db.Close() // nolint:errcheck
+	})
+
+	require.NoError(t, Migrate(context.Background(), db))
+
+	err = applyOnce(context.Background(), db, migration{
+		version:    42,
+		name:       "0042_invalid.sql",
+		statements: []string{"CREATE TABLE"},
+	})
+
+	assert.Error(t, err)
+}
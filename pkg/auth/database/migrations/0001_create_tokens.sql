@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS n26api_tokens (
+    token_key          VARCHAR(255) NOT NULL PRIMARY KEY,
+    access_token       TEXT         NOT NULL,
+    refresh_token      TEXT         NOT NULL,
+    expires_at         BIGINT       NOT NULL,
+    refresh_expires_at BIGINT       NOT NULL,
+    updated_at         BIGINT       NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS n26api_token_events (
+    token_key  VARCHAR(255) NOT NULL,
+    grant_type VARCHAR(32)  NOT NULL,
+    issued_at  BIGINT       NOT NULL,
+    expires_at BIGINT       NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS n26api_token_events_token_key_issued_at ON n26api_token_events (token_key, issued_at);
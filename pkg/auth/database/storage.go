@@ -0,0 +1,228 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/bool64/ctxd"
+	"go.nhat.io/clock"
+
+	"github.com/nhatthm/n26api/pkg/auth"
+)
+
+// ErrTokenKeyEmpty indicates that the key of token is empty and we can not persist that.
+var ErrTokenKeyEmpty = errors.New("token key is empty")
+
+var (
+	_ auth.TokenStorage   = (*TokenStorage)(nil)
+	_ auth.TokenKeyLister = (*TokenStorage)(nil)
+)
+
+// Option configures TokenStorage.
+type Option func(s *TokenStorage)
+
+// Event is a token issuance event.
+type Event struct {
+	Key       string
+	GrantType string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// TokenStorage persists auth.OAuthToken into a database/sql database. The queries work with Postgres and SQLite, the
+// tables are created by Migrate.
+type TokenStorage struct {
+	db      *sql.DB
+	history bool
+	clock   clock.Clock
+}
+
+// WithHistory records the token issuance events, see TokenStorage.History.
+func WithHistory() Option {
+	return func(s *TokenStorage) {
+		s.history = true
+	}
+}
+
+// WithClock sets the clock (for testing purpose).
+func WithClock(clock clock.Clock) Option {
+	return func(s *TokenStorage) {
+		s.clock = clock
+	}
+}
+
+// Get gets OAuthToken from the database. If the key does not exist, an empty token is returned.
+func (s *TokenStorage) Get(ctx context.Context, key string) (auth.OAuthToken, error) {
+	var (
+		accessToken, refreshToken   string
+		expiresAt, refreshExpiresAt int64
+	)
+
+	err := s.db.QueryRowContext(ctx,
+		`SELECT access_token, refresh_token, expires_at, refresh_expires_at FROM n26api_tokens WHERE token_key = $1`,
+		key,
+	).Scan(&accessToken, &refreshToken, &expiresAt, &refreshExpiresAt)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return auth.OAuthToken{}, nil
+	}
+
+	if err != nil {
+		return auth.OAuthToken{}, ctxd.WrapError(ctx, err, "could not get token", "key", key)
+	}
+
+	return auth.OAuthToken{
+		AccessToken:      auth.Token(accessToken),
+		RefreshToken:     auth.Token(refreshToken),
+		ExpiresAt:        time.Unix(0, expiresAt),
+		RefreshExpiresAt: time.Unix(0, refreshExpiresAt),
+	}, nil
+}
+
+// Set sets OAuthToken to the database. The stored token is replaced only if it does not expire later, so several
+// replicas that share the database never overwrite a fresher token.
+//
+// If the history is enabled, the issuance event is recorded in the same transaction with the grant type from
+// auth.GrantTypeFromContext.
+func (s *TokenStorage) Set(ctx context.Context, key string, token auth.OAuthToken) error {
+	if key == "" {
+		return ErrTokenKeyEmpty
+	}
+
+	if err := s.set(ctx, key, token); err != nil {
+		return ctxd.WrapError(ctx, err, "could not set token", "key", key)
+	}
+
+	return nil
+}
+
+func (s *TokenStorage) set(ctx context.Context, key string, token auth.OAuthToken) (err error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback() // nolint:errcheck
+		}
+	}()
+
+	now := s.clock.Now()
+
+	if _, err := tx.ExecContext(ctx, `INSERT INTO n26api_tokens (token_key, access_token, refresh_token, expires_at, refresh_expires_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (token_key) DO UPDATE SET
+    access_token = excluded.access_token,
+    refresh_token = excluded.refresh_token,
+    expires_at = excluded.expires_at,
+    refresh_expires_at = excluded.refresh_expires_at,
+    updated_at = excluded.updated_at
+WHERE n26api_tokens.expires_at <= excluded.expires_at`,
+		key,
+		string(token.AccessToken),
+		string(token.RefreshToken),
+		token.ExpiresAt.UnixNano(),
+		token.RefreshExpiresAt.UnixNano(),
+		now.UnixNano(),
+	); err != nil {
+		return err
+	}
+
+	if s.history {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO n26api_token_events (token_key, grant_type, issued_at, expires_at) VALUES ($1, $2, $3, $4)`,
+			key,
+			auth.GrantTypeFromContext(ctx),
+			now.UnixNano(),
+			token.ExpiresAt.UnixNano(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Keys lists the keys of the tokens.
+func (s *TokenStorage) Keys(ctx context.Context) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT token_key FROM n26api_tokens ORDER BY token_key`)
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not list token keys")
+	}
+
+	defer rows.Close() // nolint:errcheck
+
+	keys := make([]string, 0)
+
+	for rows.Next() {
+		var key string
+
+		if err := rows.Scan(&key); err != nil {
+			return nil, ctxd.WrapError(ctx, err, "could not list token keys")
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not list token keys")
+	}
+
+	return keys, nil
+}
+
+// History returns the token issuance events of a key, from the oldest to the newest.
+func (s *TokenStorage) History(ctx context.Context, key string) ([]Event, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT grant_type, issued_at, expires_at FROM n26api_token_events WHERE token_key = $1 ORDER BY issued_at`,
+		key,
+	)
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not get token history", "key", key)
+	}
+
+	defer rows.Close() // nolint:errcheck
+
+	result := make([]Event, 0)
+
+	for rows.Next() {
+		var (
+			grantType           string
+			issuedAt, expiresAt int64
+		)
+
+		if err := rows.Scan(&grantType, &issuedAt, &expiresAt); err != nil {
+			return nil, ctxd.WrapError(ctx, err, "could not get token history", "key", key)
+		}
+
+		result = append(result, Event{
+			Key:       key,
+			GrantType: grantType,
+			IssuedAt:  time.Unix(0, issuedAt),
+			ExpiresAt: time.Unix(0, expiresAt),
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not get token history", "key", key)
+	}
+
+	return result, nil
+}
+
+// NewTokenStorage initiates a new TokenStorage, the tables have to be created by Migrate.
+func NewTokenStorage(db *sql.DB, options ...Option) *TokenStorage {
+	s := &TokenStorage{
+		db:    db,
+		clock: clock.New(),
+	}
+
+	for _, o := range options {
+		o(s)
+	}
+
+	return s
+}
@@ -0,0 +1,198 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.nhat.io/clock"
+	_ "modernc.org/sqlite"
+
+	"github.com/nhatthm/n26api/pkg/auth"
+	"github.com/nhatthm/n26api/pkg/auth/database"
+)
+
+func newDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tokens.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = db.Close() // nolint:errcheck
+	})
+
+	require.NoError(t, database.Migrate(context.Background(), db))
+
+	return db
+}
+
+func newToken(accessToken string, expiresAt time.Time) auth.OAuthToken {
+	return auth.OAuthToken{
+		AccessToken:      auth.Token(accessToken),
+		RefreshToken:     auth.Token("refresh-" + accessToken),
+		ExpiresAt:        expiresAt,
+		RefreshExpiresAt: expiresAt.Add(time.Hour),
+	}
+}
+
+func assertToken(t *testing.T, expected, actual auth.OAuthToken) {
+	t.Helper()
+
+	assert.Equal(t, expected.AccessToken, actual.AccessToken)
+	assert.Equal(t, expected.RefreshToken, actual.RefreshToken)
+	assert.True(t, expected.ExpiresAt.Equal(actual.ExpiresAt))
+	assert.True(t, expected.RefreshExpiresAt.Equal(actual.RefreshExpiresAt))
+}
+
+func TestMigrate_Twice(t *testing.T) {
+	t.Parallel()
+
+	db := newDB(t)
+
+	require.NoError(t, database.Migrate(context.Background(), db))
+
+	var versions int
+
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM n26api_schema_migrations`).Scan(&versions))
+
+	assert.Equal(t, 1, versions)
+}
+
+func TestTokenStorage_GetNotFound(t *testing.T) {
+	t.Parallel()
+
+	storage := database.NewTokenStorage(newDB(t))
+
+	token, err := storage.Get(context.Background(), "unknown")
+	require.NoError(t, err)
+
+	assert.Equal(t, auth.OAuthToken{}, token)
+}
+
+func TestTokenStorage_NotMigrated(t *testing.T) {
+	t.Parallel()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "tokens.db"))
+	require.NoError(t, err)
+
+	defer db.Close() // nolint:errcheck
+
+	storage := database.NewTokenStorage(db)
+
+	_, err = storage.Get(context.Background(), "john.doe")
+	assert.ErrorContains(t, err, "could not get token")
+
+	err = storage.Set(context.Background(), "john.doe", newToken("token", time.Now()))
+	assert.ErrorContains(t, err, "could not set token")
+}
+
+func TestTokenStorage_SetEmptyKey(t *testing.T) {
+	t.Parallel()
+
+	storage := database.NewTokenStorage(newDB(t))
+
+	err := storage.Set(context.Background(), "", newToken("token", time.Now()))
+
+	assert.ErrorIs(t, err, database.ErrTokenKeyEmpty)
+}
+
+func TestTokenStorage_SetKeepsNewestToken(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	db := newDB(t)
+
+	// Two replicas share the database.
+	replica1 := database.NewTokenStorage(db)
+	replica2 := database.NewTokenStorage(db)
+
+	fresh := newToken("fresh", now.Add(10*time.Minute))
+	stale := newToken("stale", now.Add(5*time.Minute))
+	fresher := newToken("fresher", now.Add(15*time.Minute))
+
+	require.NoError(t, replica1.Set(context.Background(), "john.doe", fresh))
+	require.NoError(t, replica2.Set(context.Background(), "john.doe", stale))
+
+	actual, err := replica2.Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	assertToken(t, fresh, actual)
+
+	require.NoError(t, replica2.Set(context.Background(), "john.doe", fresher))
+
+	actual, err = replica1.Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	assertToken(t, fresher, actual)
+
+	// The other keys are not changed.
+	require.NoError(t, replica1.Set(context.Background(), "jane.doe", stale))
+
+	actual, err = replica1.Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	assertToken(t, fresher, actual)
+}
+
+func TestTokenStorage_History(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	db := newDB(t)
+
+	login := newToken("login", now.Add(5*time.Minute))
+	refresh := newToken("refresh", now.Add(10*time.Minute))
+
+	ctx := auth.ContextWithGrantType(context.Background(), auth.GrantTypeMFAOOB)
+	storage := database.NewTokenStorage(db, database.WithHistory(), database.WithClock(clock.Fix(now)))
+
+	require.NoError(t, storage.Set(ctx, "john.doe", login))
+
+	ctx = auth.ContextWithGrantType(context.Background(), auth.GrantTypeRefreshToken)
+	storage = database.NewTokenStorage(db, database.WithHistory(), database.WithClock(clock.Fix(now.Add(time.Minute))))
+
+	require.NoError(t, storage.Set(ctx, "john.doe", refresh))
+
+	// The history is not recorded.
+	require.NoError(t, database.NewTokenStorage(db).Set(ctx, "john.doe", refresh))
+
+	events, err := storage.History(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "john.doe", events[0].Key)
+	assert.Equal(t, auth.GrantTypeMFAOOB, events[0].GrantType)
+	assert.True(t, now.Equal(events[0].IssuedAt))
+	assert.True(t, login.ExpiresAt.Equal(events[0].ExpiresAt))
+
+	assert.Equal(t, "john.doe", events[1].Key)
+	assert.Equal(t, auth.GrantTypeRefreshToken, events[1].GrantType)
+	assert.True(t, now.Add(time.Minute).Equal(events[1].IssuedAt))
+	assert.True(t, refresh.ExpiresAt.Equal(events[1].ExpiresAt))
+
+	events, err = storage.History(context.Background(), "jane.doe")
+	require.NoError(t, err)
+
+	assert.Empty(t, events)
+}
+
+func TestTokenStorage_Keys(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	storage := database.NewTokenStorage(newDB(t))
+
+	require.NoError(t, storage.Set(context.Background(), "john.doe", newToken("john", now)))
+	require.NoError(t, storage.Set(context.Background(), "jane.doe", newToken("jane", now)))
+
+	keys, err := storage.Keys(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"jane.doe", "john.doe"}, keys)
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// TokenVersion is the current version of the serialized OAuthToken.
+const TokenVersion = 2
+
+// ErrUnsupportedTokenVersion indicates that the serialized token is written by a newer version and can not be decoded.
+var ErrUnsupportedTokenVersion = errors.New("unsupported token version")
+
+// tokenDecoders decode the tokens of the previous versions, and upgrade them to the current version.
+var tokenDecoders = map[int]func(data []byte) (OAuthToken, error){
+	1: decodeTokenV1,
+	2: decodeTokenV2,
+}
+
+// tokenV1 is the layout of version 1, the bare OAuthToken that was stored without envelope. It is frozen, so the
+// tokens that are already persisted can still be decoded when OAuthToken changes.
+type tokenV1 struct {
+	AccessToken      Token     `json:"access_token"`
+	RefreshToken     Token     `json:"refresh_token"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	RefreshExpiresAt time.Time `json:"refresh_expires_at"`
+}
+
+// tokenEnvelope is the serialized OAuthToken, the version tells how to decode the token.
+type tokenEnvelope struct {
+	Version *int            `json:"v"`
+	Token   json.RawMessage `json:"token"`
+}
+
+// MarshalToken serializes an OAuthToken in a versioned envelope, for example:
+//
+//	{"v":2,"token":{"access_token":"...","refresh_token":"...","expires_at":"...","refresh_expires_at":"..."}}
+//
+// Every storage that serializes the tokens should use it, so the tokens that are already persisted can be upgraded
+// when OAuthToken changes.
+func MarshalToken(token OAuthToken) ([]byte, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal token: %w", err)
+	}
+
+	version := TokenVersion
+
+	data, err = json.Marshal(tokenEnvelope{Version: &version, Token: data})
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal token: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalToken deserializes an OAuthToken of any known version, see MarshalToken. A payload without envelope is
+// decoded as version 1.
+func UnmarshalToken(data []byte) (OAuthToken, error) {
+	var envelope tokenEnvelope
+
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return OAuthToken{}, fmt.Errorf("could not unmarshal token: %w", err)
+	}
+
+	version, payload := 1, data
+
+	if envelope.Version != nil {
+		version, payload = *envelope.Version, envelope.Token
+	}
+
+	decode, ok := tokenDecoders[version]
+	if !ok {
+		return OAuthToken{}, fmt.Errorf("could not unmarshal token: %w: %d", ErrUnsupportedTokenVersion, version)
+	}
+
+	token, err := decode(payload)
+	if err != nil {
+		return OAuthToken{}, fmt.Errorf("could not unmarshal token version %d: %w", version, err)
+	}
+
+	return token, nil
+}
+
+// decodeTokenV1 decodes a bare token of version 1 and upgrades it to the current version.
+func decodeTokenV1(data []byte) (OAuthToken, error) {
+	var token tokenV1
+
+	if err := json.Unmarshal(data, &token); err != nil {
+		return OAuthToken{}, err
+	}
+
+	return OAuthToken{
+		AccessToken:      token.AccessToken,
+		RefreshToken:     token.RefreshToken,
+		ExpiresAt:        token.ExpiresAt,
+		RefreshExpiresAt: token.RefreshExpiresAt,
+	}, nil
+}
+
+// decodeTokenV2 decodes the token of an envelope of version 2.
+func decodeTokenV2(data []byte) (OAuthToken, error) {
+	var token OAuthToken
+
+	if err := json.Unmarshal(data, &token); err != nil {
+		return OAuthToken{}, err
+	}
+
+	return token, nil
+}
@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tokenV1Fixture is a token as it was stored before the envelope, a bare OAuthToken.
+const tokenV1Fixture = `{"access_token":"access","refresh_token":"refresh","expires_at":"2020-01-02T03:04:05Z","refresh_expires_at":"2020-01-02T04:04:05Z"}`
+
+func TestMarshalToken(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	token := OAuthToken{
+		AccessToken:      "access",
+		RefreshToken:     "refresh",
+		ExpiresAt:        timestamp,
+		RefreshExpiresAt: timestamp.Add(time.Hour),
+	}
+
+	data, err := MarshalToken(token)
+	require.NoError(t, err)
+
+	expected := `{"v":2,"token":{"access_token":"access","refresh_token":"refresh","expires_at":"2020-01-02T03:04:05Z","refresh_expires_at":"2020-01-02T04:04:05Z"}}`
+
+	assert.JSONEq(t, expected, string(data))
+
+	actual, err := UnmarshalToken(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, token, actual)
+}
+
+func TestUnmarshalToken(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		scenario      string
+		data          string
+		expected      OAuthToken
+		expectedError string
+	}{
+		{
+			scenario: "version 1",
+			data:     tokenV1Fixture,
+			expected: OAuthToken{
+				AccessToken:      "access",
+				RefreshToken:     "refresh",
+				ExpiresAt:        timestamp,
+				RefreshExpiresAt: timestamp.Add(time.Hour),
+			},
+		},
+		{
+			scenario:      "invalid version 1",
+			data:          `{"access_token":"access","expires_at":42}`,
+			expectedError: "could not unmarshal token version 1: json: cannot unmarshal number into Go struct field tokenV1.expires_at of type time.Time",
+		},
+		{
+			scenario: "version 2",
+			data:     `{"v":2,"token":{"access_token":"access","refresh_token":"refresh","expires_at":"2020-01-02T03:04:05Z","refresh_expires_at":"2020-01-02T04:04:05Z"}}`,
+			expected: OAuthToken{
+				AccessToken:      "access",
+				RefreshToken:     "refresh",
+				ExpiresAt:        timestamp,
+				RefreshExpiresAt: timestamp.Add(time.Hour),
+			},
+		},
+		{
+			scenario:      "unsupported version",
+			data:          `{"v":3,"token":{}}`,
+			expectedError: "could not unmarshal token: unsupported token version: 3",
+		},
+		{
+			scenario:      "invalid json",
+			data:          `not a json`,
+			expectedError: "could not unmarshal token: invalid character 'o' in literal null (expecting 'u')",
+		},
+		{
+			scenario:      "invalid token",
+			data:          `{"v":2,"token":{"expires_at":42}}`,
+			expectedError: "could not unmarshal token version 2: json: cannot unmarshal number into Go struct field OAuthToken.expires_at of type time.Time",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := UnmarshalToken([]byte(tc.data))
+
+			assert.Equal(t, tc.expected, actual)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestUnmarshalToken_UpgradeVersion1(t *testing.T) {
+	t.Parallel()
+
+	token, err := UnmarshalToken([]byte(tokenV1Fixture))
+	require.NoError(t, err)
+
+	data, err := MarshalToken(token)
+	require.NoError(t, err)
+
+	expected := `{"v":2,"token":{"access_token":"access","refresh_token":"refresh","expires_at":"2020-01-02T03:04:05Z","refresh_expires_at":"2020-01-02T04:04:05Z"}}`
+
+	assert.JSONEq(t, expected, string(data))
+}
@@ -0,0 +1,26 @@
+package auth
+
+import "context"
+
+const (
+	// GrantTypePassword is the grant type of a login with username and password.
+	GrantTypePassword = "password"
+	// GrantTypeMFAOOB is the grant type of a login confirmed on the paired device.
+	GrantTypeMFAOOB = "mfa_oob"
+	// GrantTypeRefreshToken is the grant type of a refresh.
+	GrantTypeRefreshToken = "refresh_token"
+)
+
+type ctxGrantType struct{}
+
+// ContextWithGrantType sets the grant type of the token that is being set to a TokenStorage.
+func ContextWithGrantType(ctx context.Context, grantType string) context.Context {
+	return context.WithValue(ctx, ctxGrantType{}, grantType)
+}
+
+// GrantTypeFromContext gets the grant type of the token that is being set to a TokenStorage, it is empty if unknown.
+func GrantTypeFromContext(ctx context.Context) string {
+	grantType, _ := ctx.Value(ctxGrantType{}).(string) // nolint:errcheck
+
+	return grantType
+}
@@ -0,0 +1,17 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrantTypeFromContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := ContextWithGrantType(context.Background(), GrantTypeRefreshToken)
+
+	assert.Equal(t, GrantTypeRefreshToken, GrantTypeFromContext(ctx))
+	assert.Empty(t, GrantTypeFromContext(context.Background()))
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrTokenNotJWT indicates that the token is not a JWT.
+var ErrTokenNotJWT = errors.New("token is not a jwt")
+
+// Claims are the claims of a JWT access token.
+type Claims struct {
+	// Subject is the `sub` claim, the ID of the user.
+	Subject string
+	// ExpiresAt is the `exp` claim, it is zero if the claim is missing.
+	ExpiresAt time.Time
+	// IssuedAt is the `iat` claim, it is zero if the claim is missing.
+	IssuedAt time.Time
+	// Scopes are the `scope` or `scp` claim.
+	Scopes []string
+}
+
+// jwtClaims are the raw claims, the scopes are either a space-separated string or a list.
+type jwtClaims struct {
+	Subject   string          `json:"sub"`
+	ExpiresAt *float64        `json:"exp"`
+	IssuedAt  *float64        `json:"iat"`
+	Scope     json.RawMessage `json:"scope"`
+	Scp       json.RawMessage `json:"scp"`
+}
+
+// Claims decodes the claims of a JWT access token. The signature is NOT verified, the claims must not be trusted for
+// anything else than the information of the client, for example the expiry of the token.
+func (t Token) Claims() (Claims, error) {
+	parts := strings.Split(string(t), ".")
+	if len(parts) != 3 {
+		return Claims{}, ErrTokenNotJWT
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrTokenNotJWT, err.Error())
+	}
+
+	var raw jwtClaims
+
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return Claims{}, fmt.Errorf("%w: %s", ErrTokenNotJWT, err.Error())
+	}
+
+	claims := Claims{
+		Subject:   raw.Subject,
+		ExpiresAt: numericDate(raw.ExpiresAt),
+		IssuedAt:  numericDate(raw.IssuedAt),
+	}
+
+	for _, scope := range []json.RawMessage{raw.Scope, raw.Scp} {
+		if claims.Scopes = parseScopes(scope); claims.Scopes != nil {
+			break
+		}
+	}
+
+	return claims, nil
+}
+
+// numericDate converts the seconds since epoch to time.
+func numericDate(seconds *float64) time.Time {
+	if seconds == nil {
+		return time.Time{}
+	}
+
+	return time.Unix(0, int64(*seconds*float64(time.Second))).UTC()
+}
+
+func parseScopes(data json.RawMessage) []string {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var scope string
+
+	if err := json.Unmarshal(data, &scope); err == nil {
+		return strings.Fields(scope)
+	}
+
+	var scopes []string
+
+	if err := json.Unmarshal(data, &scopes); err == nil {
+		return scopes
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func jwt(claims string) Token {
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	payload := base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+	return Token(header + "." + payload + ".signature")
+}
+
+func TestToken_Claims(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		token         Token
+		expected      Claims
+		expectedError string
+	}{
+		{
+			scenario:      "not a jwt",
+			token:         "7f9c4d3e-2b1a-4c8d-9e6f-5a4b3c2d1e0f",
+			expectedError: "token is not a jwt",
+		},
+		{
+			scenario:      "invalid payload",
+			token:         "header.%%%.signature",
+			expectedError: "token is not a jwt: illegal base64 data at input byte 0",
+		},
+		{
+			scenario:      "invalid claims",
+			token:         jwt(`[]`),
+			expectedError: "token is not a jwt: json: cannot unmarshal array into Go value of type auth.jwtClaims",
+		},
+		{
+			scenario: "no claims",
+			token:    jwt(`{}`),
+		},
+		{
+			scenario: "scope is a string",
+			token:    jwt(`{"sub":"4a9f1e0b-3c2d-4e5f-8a7b-6c5d4e3f2a1b","exp":1577934245,"iat":1577933345,"scope":"trust read"}`),
+			expected: Claims{
+				Subject:   "4a9f1e0b-3c2d-4e5f-8a7b-6c5d4e3f2a1b",
+				ExpiresAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+				IssuedAt:  time.Date(2020, 1, 2, 2, 49, 5, 0, time.UTC),
+				Scopes:    []string{"trust", "read"},
+			},
+		},
+		{
+			scenario: "scp is a list",
+			token:    jwt(`{"exp":1577934245.5,"scp":["trust","read"]}`),
+			expected: Claims{
+				ExpiresAt: time.Date(2020, 1, 2, 3, 4, 5, int(500*time.Millisecond), time.UTC),
+				Scopes:    []string{"trust", "read"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			claims, err := tc.token.Claims()
+
+			assert.Equal(t, tc.expected, claims)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+				assert.True(t, errors.Is(err, ErrTokenNotJWT))
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package auth
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenSourceFromOAuth2 adapts an oauth2.TokenSource to a TokenProvider, for example to get the tokens from a central
+// auth service. Wrap the source with oauth2.ReuseTokenSource to reuse the tokens until they expire.
+func TokenSourceFromOAuth2(source oauth2.TokenSource) TokenProvider {
+	return TokenProviderFunc(func(context.Context) (Token, error) {
+		token, err := source.Token()
+		if err != nil {
+			return "", err
+		}
+
+		return Token(token.AccessToken), nil
+	})
+}
+
+// OAuth2TokenSource adapts a TokenProvider to an oauth2.TokenSource, the context is used for all the tokens. The expiry
+// of the token is the `exp` claim if the token is a JWT, otherwise it is unknown.
+func OAuth2TokenSource(ctx context.Context, provider TokenProvider) oauth2.TokenSource {
+	return &tokenSource{ctx: ctx, provider: provider}
+}
+
+type tokenSource struct {
+	ctx      context.Context // nolint:containedctx
+	provider TokenProvider
+}
+
+// Token provides a token from the TokenProvider.
+func (s *tokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.provider.Token(s.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &oauth2.Token{
+		AccessToken: string(token),
+		TokenType:   "Bearer",
+	}
+
+	if claims, err := token.Claims(); err == nil {
+		result.Expiry = claims.ExpiresAt
+	}
+
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+func TestTokenSourceFromOAuth2(t *testing.T) {
+	t.Parallel()
+
+	p := TokenSourceFromOAuth2(oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "token"}))
+
+	token, err := p.Token(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, Token("token"), token)
+
+	p = TokenSourceFromOAuth2(tokenSourceFunc(func() (*oauth2.Token, error) {
+		return nil, errors.New("sidecar is down")
+	}))
+
+	token, err = p.Token(context.Background())
+
+	assert.Empty(t, token)
+	assert.EqualError(t, err, "sidecar is down")
+}
+
+func TestOAuth2TokenSource(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		provider      TokenProvider
+		expected      *oauth2.Token
+		expectedError string
+	}{
+		{
+			scenario: "token is not a jwt",
+			provider: StaticToken("token"),
+			expected: &oauth2.Token{AccessToken: "token", TokenType: "Bearer"},
+		},
+		{
+			scenario: "token is a jwt",
+			provider: StaticToken(jwt(`{"exp":1577934245}`)),
+			expected: &oauth2.Token{
+				AccessToken: string(jwt(`{"exp":1577934245}`)),
+				TokenType:   "Bearer",
+				Expiry:      time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		},
+		{
+			scenario: "could not get token",
+			provider: TokenProviderFunc(func(context.Context) (Token, error) {
+				return "", errors.New("could not get token")
+			}),
+			expectedError: "could not get token",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			token, err := OAuth2TokenSource(context.Background(), tc.provider).Token()
+
+			assert.Equal(t, tc.expected, token)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	_ TokenProvider = StaticToken("")
+	_ TokenProvider = (TokenProviderFunc)(nil)
+	_ TokenProvider = (*cachedTokenProvider)(nil)
+)
+
+// StaticToken is a TokenProvider that always provides the same token.
+type StaticToken Token
+
+// Token provides the static token.
+func (t StaticToken) Token(context.Context) (Token, error) {
+	return Token(t), nil
+}
+
+// TokenProviderFunc is an adapter to use a function as a TokenProvider.
+type TokenProviderFunc func(ctx context.Context) (Token, error)
+
+// Token provides a token by calling the function.
+func (f TokenProviderFunc) Token(ctx context.Context) (Token, error) {
+	return f(ctx)
+}
+
+// cachedTokenProvider caches the token of another provider.
+type cachedTokenProvider struct {
+	provider TokenProvider
+	ttl      time.Duration
+	now      func() time.Time
+
+	token     Token
+	expiresAt time.Time
+
+	mu sync.Mutex
+}
+
+// Token provides the cached token, or a new token from the provider if the cached one is expired.
+func (p *cachedTokenProvider) Token(ctx context.Context) (Token, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+
+	if p.token != "" && now.Before(p.expiresAt) {
+		return p.token, nil
+	}
+
+	token, err := p.provider.Token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	p.token = token
+	p.expiresAt = now.Add(p.ttl)
+
+	return token, nil
+}
+
+// CachedTokenProvider caches the token of a provider for the given duration. The provider is called by one goroutine at
+// a time, the other goroutines wait and share its result. The errors and the empty tokens are not cached.
+func CachedTokenProvider(provider TokenProvider, ttl time.Duration) TokenProvider {
+	return &cachedTokenProvider{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticToken(t *testing.T) {
+	t.Parallel()
+
+	token, err := StaticToken("token").Token(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, Token("token"), token)
+}
+
+func TestTokenProviderFunc(t *testing.T) {
+	t.Parallel()
+
+	p := TokenProviderFunc(func(context.Context) (Token, error) {
+		return "", errors.New("sidecar is down")
+	})
+
+	token, err := p.Token(context.Background())
+
+	assert.Empty(t, token)
+	assert.EqualError(t, err, "sidecar is down")
+}
+
+func TestCachedTokenProvider(t *testing.T) {
+	t.Parallel()
+
+	var (
+		calls int64
+		fail  atomic.Bool
+	)
+
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	tokens := []Token{"", "token1", "token2", "token3"}
+
+	p := CachedTokenProvider(TokenProviderFunc(func(context.Context) (Token, error) {
+		if fail.Load() {
+			return "", errors.New("sidecar is down")
+		}
+
+		return tokens[atomic.AddInt64(&calls, 1)], nil
+	}), time.Minute).(*cachedTokenProvider)
+
+	p.now = func() time.Time {
+		return now
+	}
+
+	// The goroutines share the result of the first call.
+	var wg sync.WaitGroup
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			token, err := p.Token(context.Background())
+
+			assert.NoError(t, err)
+			assert.Equal(t, Token("token1"), token)
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int64(1), atomic.LoadInt64(&calls))
+
+	// The token is expired, the error is not cached.
+	now = now.Add(time.Minute)
+
+	fail.Store(true)
+
+	token, err := p.Token(context.Background())
+
+	assert.Empty(t, token)
+	assert.EqualError(t, err, "sidecar is down")
+
+	fail.Store(false)
+
+	token, err = p.Token(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, Token("token2"), token)
+	assert.Equal(t, int64(2), atomic.LoadInt64(&calls))
+}
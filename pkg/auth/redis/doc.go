@@ -0,0 +1,2 @@
+// Package redis provides an auth.TokenStorage backed by a Redis-protocol server.
+package redis
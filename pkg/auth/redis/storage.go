@@ -0,0 +1,199 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/bool64/ctxd"
+	"github.com/redis/go-redis/v9"
+	"go.nhat.io/clock"
+
+	"github.com/nhatthm/n26api/pkg/auth"
+)
+
+// DefaultMaxRetries is the default number of attempts to set a token when the key is changed by another writer.
+const DefaultMaxRetries = 3
+
+var (
+	// ErrTokenKeyEmpty indicates that the key of token is empty and we can not persist that.
+	ErrTokenKeyEmpty = errors.New("token key is empty")
+	// ErrTooManyRetries indicates that the token could not be set because the key kept changing.
+	ErrTooManyRetries = errors.New("too many retries")
+)
+
+var (
+	_ auth.TokenStorage   = (*TokenStorage)(nil)
+	_ auth.TokenKeyLister = (*TokenStorage)(nil)
+)
+
+// Option configures TokenStorage.
+type Option func(s *TokenStorage)
+
+// TokenStorage persists auth.OAuthToken in a Redis-protocol server, see auth.MarshalToken. The key expires when the refresh token
+// expires.
+type TokenStorage struct {
+	client     redis.UniversalClient
+	prefix     string
+	maxRetries int
+	clock      clock.Clock
+}
+
+// WithKeyPrefix sets the prefix of the keys, for example "n26api:tokens:", so that several applications can share the
+// same server.
+func WithKeyPrefix(prefix string) Option {
+	return func(s *TokenStorage) {
+		s.prefix = prefix
+	}
+}
+
+// WithMaxRetries sets the number of attempts to set a token when the key is changed by another writer. The token is
+// always set at least once.
+func WithMaxRetries(maxRetries int) Option {
+	return func(s *TokenStorage) {
+		s.maxRetries = maxRetries
+	}
+}
+
+// WithClock sets the clock (for testing purpose).
+func WithClock(clock clock.Clock) Option {
+	return func(s *TokenStorage) {
+		s.clock = clock
+	}
+}
+
+// Get gets OAuthToken from the server. If the key does not exist or is expired, an empty token is returned.
+func (s *TokenStorage) Get(ctx context.Context, key string) (auth.OAuthToken, error) {
+	token, err := get(ctx, s.client, s.prefix+key)
+	if err != nil {
+		return auth.OAuthToken{}, ctxd.WrapError(ctx, err, "could not get token", "key", s.prefix+key)
+	}
+
+	return token, nil
+}
+
+// Set sets OAuthToken to the server, the key expires when the refresh token expires. If the refresh token is already
+// expired, the key is deleted instead.
+//
+// The token is set only if the stored token does not expire later, so several replicas that share the server never
+// overwrite a fresher token. The check and the write are done in an optimistic transaction, which is retried when the
+// key is changed by another writer in the meantime.
+func (s *TokenStorage) Set(ctx context.Context, key string, token auth.OAuthToken) error {
+	if key == "" {
+		return ErrTokenKeyEmpty
+	}
+
+	key = s.prefix + key
+
+	ttl := token.RefreshExpiresAt.Sub(s.clock.Now())
+
+	data, err := auth.MarshalToken(token)
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not set token", "key", key)
+	}
+
+	set := func(tx *redis.Tx) error {
+		current, err := get(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+
+		if current.ExpiresAt.After(token.ExpiresAt) {
+			return nil
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			if ttl <= 0 {
+				// The token can not be used anymore, the stored token is not fresher and is removed too.
+				pipe.Del(ctx, key)
+			} else {
+				pipe.Set(ctx, key, data, ttl)
+			}
+
+			return nil
+		})
+
+		return err
+	}
+
+	// The token is set at least once, whatever the number of retries.
+	for i := 1; ; i++ {
+		err = s.client.Watch(ctx, set, key)
+		if !errors.Is(err, redis.TxFailedErr) || i >= s.maxRetries {
+			break
+		}
+	}
+
+	if errors.Is(err, redis.TxFailedErr) {
+		err = ErrTooManyRetries
+	}
+
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not set token", "key", key)
+	}
+
+	return nil
+}
+
+// Keys lists the keys of the tokens that have the prefix, without the prefix.
+func (s *TokenStorage) Keys(ctx context.Context) ([]string, error) {
+	keys := make([]string, 0)
+	iter := s.client.Scan(ctx, 0, escapePattern(s.prefix)+"*", 0).Iterator()
+
+	for iter.Next(ctx) {
+		keys = append(keys, strings.TrimPrefix(iter.Val(), s.prefix))
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not list token keys", "prefix", s.prefix)
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func get(ctx context.Context, c redis.Cmdable, key string) (auth.OAuthToken, error) {
+	data, err := c.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return auth.OAuthToken{}, nil
+	}
+
+	if err != nil {
+		return auth.OAuthToken{}, err
+	}
+
+	return auth.UnmarshalToken(data)
+}
+
+// escapePattern escapes the special characters of a glob-style pattern.
+func escapePattern(s string) string {
+	var sb strings.Builder
+
+	for _, r := range s {
+		switch r {
+		case '*', '?', '[', ']', '\\':
+			sb.WriteRune('\\')
+		}
+
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// NewTokenStorage initiates a new TokenStorage.
+func NewTokenStorage(client redis.UniversalClient, options ...Option) *TokenStorage {
+	s := &TokenStorage{
+		client:     client,
+		maxRetries: DefaultMaxRetries,
+		clock:      clock.New(),
+	}
+
+	for _, o := range options {
+		o(s)
+	}
+
+	return s
+}
@@ -0,0 +1,285 @@
+package redis_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.nhat.io/clock"
+
+	"github.com/nhatthm/n26api/pkg/auth"
+	"github.com/nhatthm/n26api/pkg/auth/redis"
+)
+
+func newStorage(t *testing.T, options ...redis.Option) (*redis.TokenStorage, *miniredis.Miniredis) {
+	t.Helper()
+
+	s := miniredis.RunT(t)
+	c := goredis.NewClient(&goredis.Options{Addr: s.Addr()})
+
+	t.Cleanup(func() {
+		_ = c.Close() // nolint:errcheck
+	})
+
+	return redis.NewTokenStorage(c, options...), s
+}
+
+func newToken(accessToken string, expiresAt time.Time) auth.OAuthToken {
+	return auth.OAuthToken{
+		AccessToken:      auth.Token(accessToken),
+		RefreshToken:     auth.Token("refresh-" + accessToken),
+		ExpiresAt:        expiresAt.UTC(),
+		RefreshExpiresAt: expiresAt.Add(time.Hour).UTC(),
+	}
+}
+
+func TestTokenStorage_GetNotFound(t *testing.T) {
+	t.Parallel()
+
+	storage, _ := newStorage(t)
+
+	token, err := storage.Get(context.Background(), "unknown")
+	require.NoError(t, err)
+
+	assert.Equal(t, auth.OAuthToken{}, token)
+}
+
+func TestTokenStorage_GetInvalidToken(t *testing.T) {
+	t.Parallel()
+
+	storage, s := newStorage(t)
+
+	require.NoError(t, s.Set("key", "not a json"))
+
+	token, err := storage.Get(context.Background(), "key")
+
+	assert.Equal(t, auth.OAuthToken{}, token)
+	assert.ErrorContains(t, err, "could not get token")
+}
+
+func TestTokenStorage_SetEmptyKey(t *testing.T) {
+	t.Parallel()
+
+	storage, _ := newStorage(t)
+
+	err := storage.Set(context.Background(), "", newToken("token", time.Now()))
+
+	assert.ErrorIs(t, err, redis.ErrTokenKeyEmpty)
+}
+
+func TestTokenStorage_SetAndGet(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	storage, s := newStorage(t,
+		redis.WithKeyPrefix("n26api:tokens:"),
+		redis.WithClock(clock.Fix(now)),
+	)
+
+	token := newToken("token", now.Add(5*time.Minute))
+
+	err := storage.Set(context.Background(), "john.doe", token)
+	require.NoError(t, err)
+
+	assert.True(t, s.Exists("n26api:tokens:john.doe"))
+	assert.False(t, s.Exists("john.doe"))
+
+	// The key expires when the refresh token expires.
+	assert.Equal(t, 65*time.Minute, s.TTL("n26api:tokens:john.doe"))
+
+	actual, err := storage.Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	assert.True(t, token.ExpiresAt.Equal(actual.ExpiresAt))
+	assert.True(t, token.RefreshExpiresAt.Equal(actual.RefreshExpiresAt))
+	assert.Equal(t, token.AccessToken, actual.AccessToken)
+	assert.Equal(t, token.RefreshToken, actual.RefreshToken)
+
+	s.FastForward(65 * time.Minute)
+
+	actual, err = storage.Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	assert.Equal(t, auth.OAuthToken{}, actual)
+}
+
+func TestTokenStorage_SetExpiredToken(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	storage, s := newStorage(t, redis.WithClock(clock.Fix(now)))
+
+	err := storage.Set(context.Background(), "john.doe", newToken("token", now.Add(-2*time.Hour)))
+	require.NoError(t, err)
+
+	assert.False(t, s.Exists("john.doe"))
+}
+
+func TestTokenStorage_SetExpiredTokenDeletesKey(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	storage, s := newStorage(t, redis.WithClock(clock.Fix(now)))
+
+	stored := newToken("token", now.Add(-30*time.Minute))
+	expired := stored
+	expired.RefreshExpiresAt = now.Add(-time.Minute)
+
+	require.NoError(t, storage.Set(context.Background(), "john.doe", stored))
+	require.True(t, s.Exists("john.doe"))
+
+	require.NoError(t, storage.Set(context.Background(), "john.doe", expired))
+
+	assert.False(t, s.Exists("john.doe"))
+}
+
+func TestTokenStorage_SetExpiredTokenKeepsFresherToken(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	storage, _ := newStorage(t, redis.WithClock(clock.Fix(now)))
+
+	require.NoError(t, storage.Set(context.Background(), "john.doe", newToken("fresh", now.Add(5*time.Minute))))
+	require.NoError(t, storage.Set(context.Background(), "john.doe", auth.OAuthToken{}))
+
+	actual, err := storage.Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	assert.Equal(t, auth.Token("fresh"), actual.AccessToken)
+}
+
+func TestTokenStorage_SetWithoutRetries(t *testing.T) {
+	t.Parallel()
+
+	for _, maxRetries := range []int{0, -1} {
+		now := time.Now()
+		storage, s := newStorage(t, redis.WithClock(clock.Fix(now)), redis.WithMaxRetries(maxRetries))
+
+		require.NoError(t, storage.Set(context.Background(), "john.doe", newToken("token", now.Add(5*time.Minute))))
+
+		assert.True(t, s.Exists("john.doe"), "max retries: %d", maxRetries)
+	}
+}
+
+func TestTokenStorage_SetDoesNotOverwriteFresherToken(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	storage, _ := newStorage(t, redis.WithClock(clock.Fix(now)))
+
+	fresh := newToken("fresh", now.Add(10*time.Minute))
+	stale := newToken("stale", now.Add(5*time.Minute))
+	fresher := newToken("fresher", now.Add(15*time.Minute))
+
+	require.NoError(t, storage.Set(context.Background(), "john.doe", fresh))
+	require.NoError(t, storage.Set(context.Background(), "john.doe", stale))
+
+	actual, err := storage.Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	assert.Equal(t, auth.Token("fresh"), actual.AccessToken)
+
+	require.NoError(t, storage.Set(context.Background(), "john.doe", fresher))
+
+	actual, err = storage.Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	assert.Equal(t, auth.Token("fresher"), actual.AccessToken)
+}
+
+func TestTokenStorage_SetConcurrently(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	s := miniredis.RunT(t)
+
+	// Each replica has its own connection.
+	replicas := make([]*redis.TokenStorage, 5)
+
+	for i := range replicas {
+		c := goredis.NewClient(&goredis.Options{Addr: s.Addr()})
+
+		t.Cleanup(func() {
+			_ = c.Close() // nolint:errcheck
+		})
+
+		replicas[i] = redis.NewTokenStorage(c, redis.WithClock(clock.Fix(now)), redis.WithMaxRetries(100))
+	}
+
+	var wg sync.WaitGroup
+
+	for i, storage := range replicas {
+		wg.Add(1)
+
+		go func(i int, storage *redis.TokenStorage) {
+			defer wg.Done()
+
+			for j := 0; j < 10; j++ {
+				token := newToken("token", now.Add(time.Duration(i*10+j)*time.Minute))
+
+				assert.NoError(t, storage.Set(context.Background(), "john.doe", token))
+			}
+		}(i, storage)
+	}
+
+	wg.Wait()
+
+	actual, err := replicas[0].Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	assert.True(t, now.Add(49*time.Minute).UTC().Equal(actual.ExpiresAt))
+}
+
+func TestTokenStorage_GetVersion1(t *testing.T) {
+	t.Parallel()
+
+	storage, s := newStorage(t)
+
+	require.NoError(t, s.Set("john.doe", `{"access_token":"access","refresh_token":"refresh","expires_at":"2020-01-02T03:04:05Z","refresh_expires_at":"2020-01-02T04:04:05Z"}`))
+
+	token, err := storage.Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	expected := auth.OAuthToken{
+		AccessToken:      "access",
+		RefreshToken:     "refresh",
+		ExpiresAt:        time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		RefreshExpiresAt: time.Date(2020, 1, 2, 4, 4, 5, 0, time.UTC),
+	}
+
+	assert.Equal(t, expected, token)
+}
+
+func TestTokenStorage_SetVersion(t *testing.T) {
+	t.Parallel()
+
+	storage, s := newStorage(t)
+
+	require.NoError(t, storage.Set(context.Background(), "john.doe", newToken("token", time.Now())))
+
+	data, err := s.Get("john.doe")
+	require.NoError(t, err)
+
+	assert.Contains(t, data, `"v":2`)
+}
+
+func TestTokenStorage_Keys(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	storage, s := newStorage(t, redis.WithKeyPrefix("n26api:*:"))
+
+	require.NoError(t, storage.Set(context.Background(), "john.doe", newToken("john", now)))
+	require.NoError(t, storage.Set(context.Background(), "jane.doe", newToken("jane", now)))
+	require.NoError(t, s.Set("n26api:other:jim.doe", "other"))
+
+	keys, err := storage.Keys(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"jane.doe", "john.doe"}, keys)
+}
@@ -22,3 +22,9 @@ type TokenStorage interface {
 	// Set sets OAuthToken to data source.
 	Set(ctx context.Context, key string, token OAuthToken) error
 }
+
+// TokenKeyLister lists the keys of the tokens in a TokenStorage.
+type TokenKeyLister interface {
+	// Keys lists the keys of the tokens.
+	Keys(ctx context.Context) ([]string, error)
+}
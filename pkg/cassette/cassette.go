@@ -0,0 +1,116 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"unicode/utf8"
+)
+
+// Cassette is a list of recorded interactions, in the order they happened.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a request and its response.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a recorded request. The URI is the path and the query, without the host, so the cassette can be
+// replayed with any base URL.
+type Request struct {
+	Method string      `json:"method"`
+	URI    string      `json:"uri"`
+	Header http.Header `json:"header,omitempty"`
+	Body   Body        `json:"body,omitempty"`
+}
+
+// Response is a recorded response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       Body        `json:"body,omitempty"`
+}
+
+// Body is the body of a request or a response. It is written as a string if it is a valid UTF-8 text, otherwise it is
+// written as {"base64": "..."}.
+type Body []byte
+
+type binaryBody struct {
+	Base64 string `json:"base64"`
+}
+
+// MarshalJSON marshals the body.
+func (b Body) MarshalJSON() ([]byte, error) {
+	if utf8.Valid(b) {
+		return json.Marshal(string(b))
+	}
+
+	return json.Marshal(binaryBody{Base64: base64.StdEncoding.EncodeToString(b)})
+}
+
+// UnmarshalJSON unmarshals the body.
+func (b *Body) UnmarshalJSON(data []byte) error {
+	var s string
+
+	if err := json.Unmarshal(data, &s); err == nil {
+		*b = Body(s)
+
+		return nil
+	}
+
+	var bin binaryBody
+
+	if err := json.Unmarshal(data, &bin); err != nil {
+		return err
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(bin.Base64)
+	if err != nil {
+		return err
+	}
+
+	*b = decoded
+
+	return nil
+}
+
+// Load loads a cassette from a file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path) // nolint: gosec
+	if err != nil {
+		return nil, fmt.Errorf("could not load cassette: %w", err)
+	}
+
+	var c Cassette
+
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("could not load cassette: %w", err)
+	}
+
+	return &c, nil
+}
+
+// Save saves the cassette to a file.
+func (c *Cassette) Save(path string) error {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+
+	if err := enc.Encode(c); err != nil {
+		return fmt.Errorf("could not save cassette: %w", err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("could not save cassette: %w", err)
+	}
+
+	return nil
+}
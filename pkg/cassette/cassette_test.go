@@ -0,0 +1,108 @@
+package cassette_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api/pkg/cassette"
+)
+
+func TestBody_JSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario string
+		body     cassette.Body
+		expected string
+	}{
+		{
+			scenario: "text",
+			body:     cassette.Body(`{"id":"42"}`),
+			expected: `"{\"id\":\"42\"}"`,
+		},
+		{
+			scenario: "binary",
+			body:     cassette.Body{0xff, 0xfe, 0x00},
+			expected: `{"base64":"//4A"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, tc.expected, string(data))
+
+			var actual cassette.Body
+
+			err = json.Unmarshal(data, &actual)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.body, actual)
+		})
+	}
+}
+
+func TestBody_UnmarshalJSON_Error(t *testing.T) {
+	t.Parallel()
+
+	var b cassette.Body
+
+	assert.Error(t, json.Unmarshal([]byte(`{"base64":"!"}`), &b))
+	assert.Error(t, json.Unmarshal([]byte(`42`), &b))
+}
+
+func TestCassette_SaveAndLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	expected := &cassette.Cassette{
+		Interactions: []cassette.Interaction{
+			{
+				Request: cassette.Request{
+					Method: http.MethodGet,
+					URI:    "/api/me?full=true",
+					Header: http.Header{"Accept": {"application/json"}},
+				},
+				Response: cassette.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"application/json"}},
+					Body:       cassette.Body(`{"id":"42"}`),
+				},
+			},
+		},
+	}
+
+	err := expected.Save(path)
+	require.NoError(t, err)
+
+	actual, err := cassette.Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestLoad_Error(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.json")
+
+	require.NoError(t, os.WriteFile(invalid, []byte(`{`), 0o600))
+
+	_, err := cassette.Load(filepath.Join(dir, "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = cassette.Load(invalid)
+	assert.EqualError(t, err, "could not load cassette: unexpected end of JSON input")
+}
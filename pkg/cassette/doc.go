@@ -0,0 +1,17 @@
+// Package cassette records the requests to N26 and their responses to a cassette file, and replays them, so that a
+// real session can be captured once and used in the tests without the network.
+//
+// The tokens, the credentials and the personal data are scrubbed before the cassette is written, and the ids in the
+// URIs and in the bodies are replaced with RedactedUUID. Only the JSON and the form bodies can be scrubbed, the other
+// bodies, for example the attachments, and the bodies that can not be decoded are replaced with Redacted:
+//
+//	recorder := cassette.NewRecorder("testdata/devices.json")
+//
+//	c := n26api.NewClient(
+//		n26api.WithCredentials(username, password),
+//		n26api.WithTransport(recorder),
+//	)
+//
+// The cassette is replayed by a Replayer or by a testkit.Server, see testkit.MockCassetteServer. The scrubbed ids and
+// query values match any value, see MatchURI.
+package cassette
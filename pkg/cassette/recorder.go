@@ -0,0 +1,164 @@
+package cassette
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/bool64/ctxd"
+)
+
+var _ http.RoundTripper = (*Recorder)(nil)
+
+// Option configures Recorder.
+type Option func(r *Recorder)
+
+// Recorder is a http.RoundTripper that records the requests and the responses to a cassette file. The file is written
+// after each interaction, so the cassette is complete even if the program stops, and an existing file is overwritten.
+type Recorder struct {
+	path      string
+	transport http.RoundTripper
+	scrubber  *scrubber
+	scrubbers []Scrubber
+
+	cassette Cassette
+	mu       sync.Mutex
+}
+
+// WithTransport sets the transport that sends the requests, http.DefaultTransport is used by default.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(r *Recorder) {
+		r.transport = transport
+	}
+}
+
+// WithScrubbedHeaders scrubs more headers, in addition to the authorization, the cookies and the device token.
+func WithScrubbedHeaders(headers ...string) Option {
+	return func(r *Recorder) {
+		r.scrubber.addHeaders(headers...)
+	}
+}
+
+// WithScrubbedKeys scrubs more form fields, query parameters and JSON keys, in addition to the credentials, the tokens
+// and the personal data. The keys are case-insensitive, and "_" and "-" are ignored. The nested values of a JSON key are
+// scrubbed too.
+func WithScrubbedKeys(keys ...string) Option {
+	return func(r *Recorder) {
+		r.scrubber.addKeys(keys...)
+	}
+}
+
+// WithScrubber adds a scrubber that is applied after the default scrubbing.
+func WithScrubber(s Scrubber) Option {
+	return func(r *Recorder) {
+		r.scrubbers = append(r.scrubbers, s)
+	}
+}
+
+// RoundTrip sends the request and records the interaction.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+
+	reqBody, req, err := readRequestBody(req)
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not record request", "method", req.Method, "uri", req.URL.RequestURI())
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close() // nolint: errcheck
+
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not record response", "method", req.Method, "uri", req.URL.RequestURI())
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	i := Interaction{
+		Request: Request{
+			Method: req.Method,
+			URI:    req.URL.RequestURI(),
+			Header: req.Header.Clone(),
+			Body:   reqBody,
+		},
+		Response: Response{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       respBody,
+		},
+	}
+
+	if err := r.record(i); err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not record interaction", "method", req.Method, "uri", req.URL.RequestURI())
+	}
+
+	return resp, nil
+}
+
+func (r *Recorder) record(i Interaction) error {
+	r.scrubber.scrub(&i)
+
+	for _, s := range r.scrubbers {
+		s(&i)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, i)
+
+	return r.cassette.Save(r.path)
+}
+
+// readRequestBody reads the body of the request without consuming it. The request is cloned if the body can not be
+// read again.
+func readRequestBody(req *http.Request) (Body, *http.Request, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, req, nil
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, req, err
+		}
+
+		defer body.Close() // nolint: errcheck
+
+		data, err := io.ReadAll(body)
+
+		return data, req, err
+	}
+
+	data, err := io.ReadAll(req.Body)
+	_ = req.Body.Close() // nolint: errcheck
+
+	if err != nil {
+		return nil, req, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(data))
+
+	return data, req, nil
+}
+
+// NewRecorder initiates a new Recorder that writes the cassette to the given path.
+func NewRecorder(path string, options ...Option) *Recorder {
+	r := &Recorder{
+		path:      path,
+		transport: http.DefaultTransport,
+		scrubber:  newScrubber(),
+	}
+
+	for _, o := range options {
+		o(r)
+	}
+
+	return r
+}
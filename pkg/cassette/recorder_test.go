@@ -0,0 +1,301 @@
+package cassette_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api/pkg/cassette"
+)
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func respond(statusCode int, contentType, body string) roundTripperFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		if r.Body != nil {
+			_, _ = io.ReadAll(r.Body) // nolint: errcheck
+			_ = r.Body.Close()        // nolint: errcheck
+		}
+
+		return &http.Response{
+			StatusCode: statusCode,
+			Header:     http.Header{"Content-Type": {contentType}, "Set-Cookie": {"session=secret-session"}},
+			Body:       io.NopCloser(strings.NewReader(body)),
+			Request:    r,
+		}, nil
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	tokenResponse := `{"access_token":"secret-access","refresh_token":"secret-refresh","expires_in":900,"token_type":"bearer"}`
+
+	r := cassette.NewRecorder(path,
+		cassette.WithTransport(respond(http.StatusOK, "application/json", tokenResponse)),
+	)
+
+	req, err := http.NewRequest(http.MethodPost, "https://api.tech26.de/oauth/token?lang=en",
+		strings.NewReader("grant_type=password&username=john.doe&password=secret-password"),
+	)
+	require.NoError(t, err)
+
+	req.Header.Set("Authorization", "Basic secret-basic")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("device-token", "secret-device")
+
+	resp, err := r.RoundTrip(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	// The response is not consumed by the recorder.
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, tokenResponse, string(body))
+
+	// The request is not changed by the recorder.
+	assert.Equal(t, "Basic secret-basic", req.Header.Get("Authorization"))
+
+	data, err := os.ReadFile(path) // nolint: gosec
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), "secret")
+
+	c, err := cassette.Load(path)
+	require.NoError(t, err)
+
+	expected := &cassette.Cassette{
+		Interactions: []cassette.Interaction{
+			{
+				Request: cassette.Request{
+					Method: http.MethodPost,
+					URI:    "/oauth/token?lang=en",
+					Header: http.Header{
+						"Authorization": {cassette.Redacted},
+						"Content-Type":  {"application/x-www-form-urlencoded"},
+						"Device-Token":  {cassette.Redacted},
+					},
+					Body: cassette.Body("grant_type=password&password=%5BREDACTED%5D&username=%5BREDACTED%5D"),
+				},
+				Response: cassette.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"Content-Type": {"application/json"},
+						"Set-Cookie":   {cassette.Redacted},
+					},
+					Body: cassette.Body(`{"access_token":"[REDACTED]","expires_in":900,"refresh_token":"[REDACTED]","token_type":"bearer"}`),
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, c)
+}
+
+func TestRecorder_ScrubPersonalData(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	transactions := `[{"id":"1","amount":-4.2,"partnerName":"John Doe","partnerIban":"DE123","referenceText":"Rent","tags":["home"]}]`
+
+	r := cassette.NewRecorder(path,
+		cassette.WithTransport(respond(http.StatusOK, "application/json;charset=UTF-8", transactions)),
+		cassette.WithScrubbedKeys("tags"),
+		cassette.WithScrubbedHeaders("X-Request-Id"),
+		cassette.WithScrubber(func(i *cassette.Interaction) {
+			i.Request.URI = strings.ReplaceAll(i.Request.URI, "from=1", "from=0")
+		}),
+	)
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.tech26.de/api/smrt/transactions?from=1", nil)
+	require.NoError(t, err)
+
+	req.Header.Set("X-Request-Id", "42")
+
+	resp, err := r.RoundTrip(req)
+	require.NoError(t, err)
+
+	_ = resp.Body.Close() // nolint: errcheck
+
+	c, err := cassette.Load(path)
+	require.NoError(t, err)
+
+	require.Len(t, c.Interactions, 1)
+
+	i := c.Interactions[0]
+
+	assert.Equal(t, "/api/smrt/transactions?from=0", i.Request.URI)
+	assert.Equal(t, cassette.Redacted, i.Request.Header.Get("X-Request-Id"))
+	assert.Empty(t, i.Request.Body)
+	assert.JSONEq(t,
+		`[{"id":"1","amount":-4.2,"partnerName":"[REDACTED]","partnerIban":"[REDACTED]","referenceText":"[REDACTED]","tags":["[REDACTED]"]}]`,
+		string(i.Response.Body),
+	)
+}
+
+func TestRecorder_ScrubURI(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	deviceToken := "3d51450a-504d-4801-88fa-88a195d3df7f"
+	lastID := "9f7b6c1e-2f4a-4e0c-8a57-3c3e1b7f2d11"
+
+	r := cassette.NewRecorder(path,
+		cassette.WithTransport(respond(http.StatusNoContent, "application/json", "")),
+		cassette.WithScrubbedKeys("q"),
+	)
+
+	for _, uri := range []string{
+		"https://api.tech26.de/api/me/devices/" + deviceToken,
+		"https://api.tech26.de/api/smrt/transactions?limit=1&lastId=" + lastID + "&q=john.doe",
+	} {
+		req, err := http.NewRequest(http.MethodDelete, uri, nil)
+		require.NoError(t, err)
+
+		resp, err := r.RoundTrip(req)
+		require.NoError(t, err)
+
+		_ = resp.Body.Close() // nolint: errcheck
+	}
+
+	data, err := os.ReadFile(path) // nolint: gosec
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(data), deviceToken)
+	assert.NotContains(t, string(data), lastID)
+	assert.NotContains(t, string(data), "john.doe")
+
+	c, err := cassette.Load(path)
+	require.NoError(t, err)
+
+	require.Len(t, c.Interactions, 2)
+
+	assert.Equal(t, "/api/me/devices/"+cassette.RedactedUUID, c.Interactions[0].Request.URI)
+	assert.Equal(t, "/api/smrt/transactions?lastId="+cassette.RedactedUUID+"&limit=1&q=%5BREDACTED%5D", c.Interactions[1].Request.URI)
+}
+
+func TestRecorder_ScrubUnknownBody(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario    string
+		contentType string
+		body        string
+	}{
+		{
+			scenario:    "malformed json",
+			contentType: "application/json",
+			body:        `{"access_token":"secret-token"`,
+		},
+		{
+			scenario:    "json with trailing data",
+			contentType: "application/json",
+			body:        `{"id":"1"} {"access_token":"secret-token"}`,
+		},
+		{
+			scenario:    "not json",
+			contentType: "text/plain",
+			body:        "access_token=secret-token",
+		},
+		{
+			scenario:    "malformed content type",
+			contentType: "application/json;;",
+			body:        `{"access_token":"secret-token"}`,
+		},
+		{
+			scenario: "no content type",
+			body:     `{"access_token":"secret-token"}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "cassette.json")
+
+			r := cassette.NewRecorder(path,
+				cassette.WithTransport(respond(http.StatusOK, tc.contentType, tc.body)),
+			)
+
+			req, err := http.NewRequest(http.MethodGet, "https://api.tech26.de/api/me", nil)
+			require.NoError(t, err)
+
+			resp, err := r.RoundTrip(req)
+			require.NoError(t, err)
+
+			// The response is not changed, only the cassette is scrubbed.
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			_ = resp.Body.Close() // nolint: errcheck
+
+			assert.Equal(t, tc.body, string(body))
+
+			data, err := os.ReadFile(path) // nolint: gosec
+			require.NoError(t, err)
+
+			assert.NotContains(t, string(data), "secret-token")
+
+			c, err := cassette.Load(path)
+			require.NoError(t, err)
+
+			require.Len(t, c.Interactions, 1)
+
+			assert.Equal(t, cassette.Body(cassette.Redacted), c.Interactions[0].Response.Body)
+		})
+	}
+}
+
+func TestRecorder_TransportError(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	r := cassette.NewRecorder(path,
+		cassette.WithTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("transport error")
+		})),
+	)
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.tech26.de/api/me", nil)
+	require.NoError(t, err)
+
+	_, err = r.RoundTrip(req) // nolint: bodyclose
+	assert.EqualError(t, err, "transport error")
+
+	_, err = os.Stat(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestRecorder_SaveError(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "missing", "cassette.json")
+
+	r := cassette.NewRecorder(path,
+		cassette.WithTransport(respond(http.StatusOK, "application/json", `{}`)),
+	)
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.tech26.de/api/me", nil)
+	require.NoError(t, err)
+
+	_, err = r.RoundTrip(req) // nolint: bodyclose
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	assert.ErrorContains(t, err, "could not record interaction: could not save cassette")
+}
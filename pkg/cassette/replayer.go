@@ -0,0 +1,97 @@
+package cassette
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/bool64/ctxd"
+)
+
+// ErrInteractionNotFound indicates that there is no recorded interaction for the request.
+var ErrInteractionNotFound = errors.New("interaction not found")
+
+var _ http.RoundTripper = (*Replayer)(nil)
+
+// Replayer is a http.RoundTripper that replays the interactions of a cassette without sending the requests. A request
+// is answered by the first interaction that has the same method and URI, and has not been replayed yet. The scrubbed
+// ids and query values of the recorded URI match any value, see MatchURI.
+type Replayer struct {
+	cassette *Cassette
+	replayed []bool
+
+	mu sync.Mutex
+}
+
+// RoundTrip replays the response of the request.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		_ = req.Body.Close() // nolint: errcheck
+	}
+
+	uri := req.URL.RequestURI()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || interaction.Request.Method != req.Method || !MatchURI(interaction.Request.URI, uri) {
+			continue
+		}
+
+		r.replayed[i] = true
+
+		return newResponse(req, interaction.Response), nil
+	}
+
+	return nil, ctxd.WrapError(req.Context(), ErrInteractionNotFound, "could not replay request",
+		"method", req.Method,
+		"uri", uri,
+	)
+}
+
+// Remaining returns the number of the interactions that have not been replayed.
+func (r *Replayer) Remaining() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	remaining := 0
+
+	for _, replayed := range r.replayed {
+		if !replayed {
+			remaining++
+		}
+	}
+
+	return remaining
+}
+
+func newResponse(req *http.Request, res Response) *http.Response {
+	header := res.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", res.StatusCode, http.StatusText(res.StatusCode)),
+		StatusCode:    res.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(res.Body)),
+		ContentLength: int64(len(res.Body)),
+		Request:       req,
+	}
+}
+
+// NewReplayer initiates a new Replayer.
+func NewReplayer(c *Cassette) *Replayer {
+	return &Replayer{
+		cassette: c,
+		replayed: make([]bool, len(c.Interactions)),
+	}
+}
@@ -0,0 +1,155 @@
+package cassette_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api/pkg/cassette"
+)
+
+func TestReplayer(t *testing.T) {
+	t.Parallel()
+
+	c := &cassette.Cassette{
+		Interactions: []cassette.Interaction{
+			{
+				Request:  cassette.Request{Method: http.MethodGet, URI: "/api/smrt/transactions?limit=1"},
+				Response: cassette.Response{StatusCode: http.StatusOK, Body: cassette.Body(`[{"id":"1"}]`)},
+			},
+			{
+				Request:  cassette.Request{Method: http.MethodGet, URI: "/api/me"},
+				Response: cassette.Response{StatusCode: http.StatusUnauthorized},
+			},
+			{
+				Request: cassette.Request{Method: http.MethodGet, URI: "/api/smrt/transactions?limit=1"},
+				Response: cassette.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"application/json"}},
+					Body:       cassette.Body(`[]`),
+				},
+			},
+		},
+	}
+
+	r := cassette.NewReplayer(c)
+	client := &http.Client{Transport: r}
+
+	get := func(url string) (int, string, http.Header) {
+		resp, err := client.Get(url) // nolint: noctx
+		require.NoError(t, err)
+
+		defer resp.Body.Close() // nolint: errcheck
+
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, string(body), resp.Header
+	}
+
+	assert.Equal(t, 3, r.Remaining())
+
+	code, body, _ := get("https://example.org/api/smrt/transactions?limit=1")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `[{"id":"1"}]`, body)
+
+	code, body, header := get("https://example.org/api/smrt/transactions?limit=1")
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `[]`, body)
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, 1, r.Remaining())
+
+	code, body, _ = get("http://localhost/api/me")
+
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Empty(t, body)
+	assert.Equal(t, 0, r.Remaining())
+}
+
+func TestReplayer_NotFound(t *testing.T) {
+	t.Parallel()
+
+	r := cassette.NewReplayer(&cassette.Cassette{
+		Interactions: []cassette.Interaction{
+			{
+				Request:  cassette.Request{Method: http.MethodGet, URI: "/api/me"},
+				Response: cassette.Response{StatusCode: http.StatusOK},
+			},
+		},
+	})
+
+	req, err := http.NewRequest(http.MethodPost, "https://example.org/api/me", nil)
+	require.NoError(t, err)
+
+	_, err = r.RoundTrip(req) // nolint: bodyclose
+
+	assert.ErrorIs(t, err, cassette.ErrInteractionNotFound)
+	assert.EqualError(t, err, "could not replay request: interaction not found")
+	assert.Equal(t, 1, r.Remaining())
+}
+
+func TestMatchURI(t *testing.T) {
+	t.Parallel()
+
+	id := "3d51450a-504d-4801-88fa-88a195d3df7f"
+
+	testCases := []struct {
+		scenario string
+		recorded string
+		actual   string
+		expected bool
+	}{
+		{
+			scenario: "same uri",
+			recorded: "/api/smrt/transactions?limit=1",
+			actual:   "/api/smrt/transactions?limit=1",
+			expected: true,
+		},
+		{
+			scenario: "different path",
+			recorded: "/api/smrt/transactions",
+			actual:   "/api/smrt/contacts",
+		},
+		{
+			scenario: "scrubbed id in path",
+			recorded: "/api/me/devices/" + cassette.RedactedUUID,
+			actual:   "/api/me/devices/" + id,
+			expected: true,
+		},
+		{
+			scenario: "scrubbed id does not match other values",
+			recorded: "/api/me/devices/" + cassette.RedactedUUID,
+			actual:   "/api/me/devices/42",
+		},
+		{
+			scenario: "scrubbed query values",
+			recorded: "/api/smrt/transactions?lastId=" + cassette.RedactedUUID + "&q=%5BREDACTED%5D",
+			actual:   "/api/smrt/transactions?q=john&lastId=" + id,
+			expected: true,
+		},
+		{
+			scenario: "different query values",
+			recorded: "/api/smrt/transactions?limit=1",
+			actual:   "/api/smrt/transactions?limit=2",
+		},
+		{
+			scenario: "missing query",
+			recorded: "/api/smrt/transactions?limit=1",
+			actual:   "/api/smrt/transactions",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, cassette.MatchURI(tc.recorded, tc.actual))
+		})
+	}
+}
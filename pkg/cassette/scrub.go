@@ -0,0 +1,386 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// Redacted replaces the scrubbed values.
+	Redacted = "[REDACTED]"
+	// RedactedUUID replaces the scrubbed ids, so that the cassette can still be decoded.
+	RedactedUUID = "00000000-0000-0000-0000-000000000000"
+)
+
+// defaultHeaders are the headers that are scrubbed by default.
+var defaultHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"Device-Token",
+}
+
+// defaultKeys are the form fields and the JSON keys that are scrubbed by default: the credentials, the tokens and the
+// personal data.
+var defaultKeys = []string{
+	"username",
+	"password",
+	"mfaToken",
+	"access_token",
+	"refresh_token",
+	"email",
+	"firstName",
+	"lastName",
+	"kycFirstName",
+	"kycLastName",
+	"birthDate",
+	"mobilePhoneNumber",
+	"iban",
+	"bic",
+	"partnerName",
+	"partnerIban",
+	"partnerBic",
+	"partnerAccountBan",
+	"partnerBcn",
+	"referenceText",
+	"name",
+	"contactName",
+	"merchantName",
+	"creditorName",
+	"mandateReference",
+	"nationality",
+	"gender",
+	"userId",
+	"accountId",
+	"cardId",
+	"deviceToken",
+	"userInfo",
+}
+
+// Scrubber removes the sensitive data from an interaction before it is recorded.
+type Scrubber func(i *Interaction)
+
+// scrubber scrubs the headers, the form fields and the JSON keys.
+type scrubber struct {
+	headers map[string]struct{}
+	keys    map[string]struct{}
+}
+
+func (s *scrubber) addHeaders(headers ...string) {
+	for _, h := range headers {
+		s.headers[http.CanonicalHeaderKey(h)] = struct{}{}
+	}
+}
+
+func (s *scrubber) addKeys(keys ...string) {
+	for _, k := range keys {
+		s.keys[normalizeKey(k)] = struct{}{}
+	}
+}
+
+func (s *scrubber) isSensitiveKey(key string) bool {
+	_, ok := s.keys[normalizeKey(key)]
+
+	return ok
+}
+
+func (s *scrubber) scrub(i *Interaction) {
+	i.Request.URI = s.scrubURI(i.Request.URI)
+	i.Request.Header = s.scrubHeader(i.Request.Header)
+	i.Request.Body = s.scrubBody(i.Request.Body, i.Request.Header.Get("Content-Type"))
+	i.Response.Header = s.scrubHeader(i.Response.Header)
+	i.Response.Body = s.scrubBody(i.Response.Body, i.Response.Header.Get("Content-Type"))
+}
+
+func (s *scrubber) scrubHeader(h http.Header) http.Header {
+	for k, values := range h {
+		if _, ok := s.headers[http.CanonicalHeaderKey(k)]; !ok {
+			continue
+		}
+
+		for i := range values {
+			values[i] = Redacted
+		}
+	}
+
+	return h
+}
+
+// scrubURI replaces the ids in the path and in the query with RedactedUUID, and the values of the sensitive query
+// parameters with Redacted.
+func (s *scrubber) scrubURI(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	segments := strings.Split(u.Path, "/")
+
+	for i, segment := range segments {
+		if isUUID(segment) {
+			segments[i] = RedactedUUID
+		}
+	}
+
+	u.Path = strings.Join(segments, "/")
+	u.RawPath = ""
+
+	if u.RawQuery == "" {
+		return u.RequestURI()
+	}
+
+	values, err := url.ParseQuery(u.RawQuery)
+	if err != nil {
+		return u.RequestURI()
+	}
+
+	for k, v := range values {
+		for i := range v {
+			v[i] = s.scrubString(k, v[i])
+		}
+	}
+
+	u.RawQuery = values.Encode()
+
+	return u.RequestURI()
+}
+
+// scrubString scrubs the value of a key, the ids are always scrubbed.
+func (s *scrubber) scrubString(key, value string) string {
+	switch {
+	case isUUID(value):
+		return RedactedUUID
+
+	case value != "" && s.isSensitiveKey(key):
+		return Redacted
+	}
+
+	return value
+}
+
+// scrubBody scrubs the ids, the form fields and the JSON keys. A body that can not be scrubbed, because its content
+// type is missing, malformed or unknown, or because it can not be decoded, is replaced with Redacted.
+func (s *scrubber) scrubBody(body Body, contentType string) Body {
+	if len(body) == 0 {
+		return body
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return Body(Redacted)
+	}
+
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		return s.scrubForm(body)
+
+	case mediaType == "application/json", strings.HasSuffix(mediaType, "+json"):
+		return s.scrubJSON(body)
+	}
+
+	return Body(Redacted)
+}
+
+func (s *scrubber) scrubForm(body Body) Body {
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return Body(Redacted)
+	}
+
+	for k, v := range values {
+		for i := range v {
+			v[i] = s.scrubString(k, v[i])
+		}
+	}
+
+	return Body(values.Encode())
+}
+
+func (s *scrubber) scrubJSON(body Body) Body {
+	var v interface{}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	if err := dec.Decode(&v); err != nil {
+		return Body(Redacted)
+	}
+
+	// Trailing data after the first value is not scrubbed.
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return Body(Redacted)
+	}
+
+	result, err := json.Marshal(s.scrubValue(v))
+	if err != nil {
+		return Body(Redacted)
+	}
+
+	return result
+}
+
+// scrubValue scrubs the values of the sensitive keys, see scrubAll, and replaces the other ids with RedactedUUID.
+func (s *scrubber) scrubValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if !s.isSensitiveKey(k) {
+				v[k] = s.scrubValue(val)
+
+				continue
+			}
+
+			v[k] = scrubAll(val)
+		}
+
+	case []interface{}:
+		for i, val := range v {
+			v[i] = s.scrubValue(val)
+		}
+
+	case string:
+		if isUUID(v) {
+			return RedactedUUID
+		}
+	}
+
+	return v
+}
+
+// scrubAll replaces all the strings with Redacted, or with RedactedUUID if they are ids, and all the numbers with 0.
+func scrubAll(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		if isUUID(v) {
+			return RedactedUUID
+		}
+
+		return Redacted
+
+	case json.Number:
+		return json.Number("0")
+
+	case map[string]interface{}:
+		for k, val := range v {
+			v[k] = scrubAll(val)
+		}
+
+	case []interface{}:
+		for i, val := range v {
+			v[i] = scrubAll(val)
+		}
+	}
+
+	return v
+}
+
+func isUUID(s string) bool {
+	if len(s) != len(RedactedUUID) {
+		return false
+	}
+
+	_, err := uuid.Parse(s)
+
+	return err == nil
+}
+
+// MatchURI reports whether a request URI matches a recorded URI. The recorded ids, RedactedUUID, match any id, and the
+// recorded query values Redacted match any value.
+func MatchURI(recorded, actual string) bool {
+	if recorded == actual {
+		return true
+	}
+
+	r, err := url.Parse(recorded)
+	if err != nil {
+		return false
+	}
+
+	a, err := url.Parse(actual)
+	if err != nil {
+		return false
+	}
+
+	if !matchPath(r.Path, a.Path) {
+		return false
+	}
+
+	rq, err := url.ParseQuery(r.RawQuery)
+	if err != nil {
+		return false
+	}
+
+	aq, err := url.ParseQuery(a.RawQuery)
+	if err != nil || len(rq) != len(aq) {
+		return false
+	}
+
+	for k, rv := range rq {
+		av, ok := aq[k]
+		if !ok || len(av) != len(rv) {
+			return false
+		}
+
+		for i := range rv {
+			if !matchValue(rv[i], av[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func matchPath(recorded, actual string) bool {
+	r := strings.Split(recorded, "/")
+	a := strings.Split(actual, "/")
+
+	if len(r) != len(a) {
+		return false
+	}
+
+	for i := range r {
+		if r[i] != a[i] && (r[i] != RedactedUUID || !isUUID(a[i])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchValue(recorded, actual string) bool {
+	switch recorded {
+	case actual, Redacted:
+		return true
+
+	case RedactedUUID:
+		return isUUID(actual)
+	}
+
+	return false
+}
+
+// normalizeKey lowercases the key and removes the separators, so that "mfaToken" and "mfa_token" are the same key.
+func normalizeKey(key string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(key))
+}
+
+func newScrubber() *scrubber {
+	s := &scrubber{
+		headers: make(map[string]struct{}, len(defaultHeaders)),
+		keys:    make(map[string]struct{}, len(defaultKeys)),
+	}
+
+	s.addHeaders(defaultHeaders...)
+	s.addKeys(defaultKeys...)
+
+	return s
+}
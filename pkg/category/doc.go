@@ -0,0 +1,2 @@
+// Package category provides contracts for N26 Category APIs.
+package category
@@ -0,0 +1,103 @@
+package category
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bool64/ctxd"
+
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+// Transaction is a transaction.Transaction with the display name of its category.
+type Transaction struct {
+	transaction.Transaction
+
+	CategoryName string `json:"categoryName" csv:"categoryName"`
+}
+
+// Resolver resolves category IDs to their display names.
+//
+// The catalog is fetched from the Finder on the first lookup and cached until Reset is called.
+type Resolver struct {
+	finder Finder
+	names  map[string]string
+
+	mu sync.Mutex
+}
+
+func (r *Resolver) load(ctx context.Context) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names != nil {
+		return r.names, nil
+	}
+
+	categories, err := r.finder.FindAllCategories(ctx)
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not find categories")
+	}
+
+	names := make(map[string]string, len(categories))
+
+	for _, c := range categories {
+		names[c.ID] = c.Name
+	}
+
+	r.names = names
+
+	return names, nil
+}
+
+// Name resolves the display name of a category. The ID is returned if the category is unknown.
+func (r *Resolver) Name(ctx context.Context, id string) (string, error) {
+	names, err := r.load(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return lookup(names, id), nil
+}
+
+// Enrich resolves the category display names of the transactions.
+func (r *Resolver) Enrich(ctx context.Context, transactions []transaction.Transaction) ([]Transaction, error) {
+	names, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Transaction, 0, len(transactions))
+
+	for _, t := range transactions {
+		result = append(result, Transaction{
+			Transaction:  t,
+			CategoryName: lookup(names, t.Category),
+		})
+	}
+
+	return result, nil
+}
+
+// Reset clears the cached catalog, the next lookup fetches it again.
+func (r *Resolver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.names = nil
+}
+
+func lookup(names map[string]string, id string) string {
+	if name, ok := names[id]; ok {
+		return name
+	}
+
+	return id
+}
+
+// NewResolver initiates a new Resolver.
+func NewResolver(finder Finder) *Resolver {
+	return &Resolver{
+		finder: finder,
+	}
+}
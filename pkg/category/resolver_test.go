@@ -0,0 +1,134 @@
+package category_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/category"
+	categoryMock "github.com/nhatthm/n26api/pkg/testkit/category"
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+func TestResolver_Name(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mockFinder    categoryMock.FinderMocker
+		id            string
+		expectedName  string
+		expectedError string
+	}{
+		{
+			scenario: "could not find categories",
+			mockFinder: categoryMock.MockFinder(func(f *categoryMock.Finder) {
+				f.On("FindAllCategories", context.Background()).
+					Return(nil, errors.New("find error"))
+			}),
+			id:            "micro-v2-food-groceries",
+			expectedError: "could not find categories: find error",
+		},
+		{
+			scenario: "unknown category",
+			mockFinder: categoryMock.MockFinder(func(f *categoryMock.Finder) {
+				f.On("FindAllCategories", context.Background()).
+					Return([]category.Category{{ID: "micro-v2-income", Name: "Income"}}, nil)
+			}),
+			id:           "micro-v2-food-groceries",
+			expectedName: "micro-v2-food-groceries",
+		},
+		{
+			scenario: "known category",
+			mockFinder: categoryMock.MockFinder(func(f *categoryMock.Finder) {
+				f.On("FindAllCategories", context.Background()).
+					Return([]category.Category{{ID: "micro-v2-food-groceries", Name: "Food & Groceries"}}, nil)
+			}),
+			id:           "micro-v2-food-groceries",
+			expectedName: "Food & Groceries",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			r := category.NewResolver(tc.mockFinder(t))
+
+			name, err := r.Name(context.Background(), tc.id)
+
+			assert.Equal(t, tc.expectedName, name)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestResolver_Enrich(t *testing.T) {
+	t.Parallel()
+
+	id1 := uuid.New()
+	id2 := uuid.New()
+
+	f := categoryMock.MockFinder(func(f *categoryMock.Finder) {
+		// The catalog is fetched only once.
+		f.On("FindAllCategories", context.Background()).
+			Return([]category.Category{{ID: "micro-v2-food-groceries", Name: "Food & Groceries"}}, nil).
+			Once()
+	})(t)
+
+	r := category.NewResolver(f)
+
+	transactions := []transaction.Transaction{
+		{ID: id1, Category: "micro-v2-food-groceries"},
+		{ID: id2, Category: "micro-v2-unknown"},
+	}
+
+	expected := []category.Transaction{
+		{Transaction: transaction.Transaction{ID: id1, Category: "micro-v2-food-groceries"}, CategoryName: "Food & Groceries"},
+		{Transaction: transaction.Transaction{ID: id2, Category: "micro-v2-unknown"}, CategoryName: "micro-v2-unknown"},
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := r.Enrich(context.Background(), transactions)
+
+		assert.Equal(t, expected, result)
+		assert.NoError(t, err)
+	}
+}
+
+func TestResolver_Reset(t *testing.T) {
+	t.Parallel()
+
+	f := categoryMock.MockFinder(func(f *categoryMock.Finder) {
+		f.On("FindAllCategories", context.Background()).
+			Return([]category.Category{{ID: "micro-v2-income", Name: "Income"}}, nil).
+			Once()
+
+		f.On("FindAllCategories", context.Background()).
+			Return([]category.Category{{ID: "micro-v2-income", Name: "Einkommen"}}, nil).
+			Once()
+	})(t)
+
+	r := category.NewResolver(f)
+
+	name, err := r.Name(context.Background(), "micro-v2-income")
+
+	assert.Equal(t, "Income", name)
+	assert.NoError(t, err)
+
+	r.Reset()
+
+	name, err = r.Name(context.Background(), "micro-v2-income")
+
+	assert.Equal(t, "Einkommen", name)
+	assert.NoError(t, err)
+}
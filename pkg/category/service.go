@@ -0,0 +1,9 @@
+package category
+
+import "context"
+
+// Finder is a service to find n26 categories.
+type Finder interface {
+	// FindAllCategories finds all categories.
+	FindAllCategories(ctx context.Context) ([]Category, error)
+}
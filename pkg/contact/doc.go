@@ -0,0 +1,2 @@
+// Package contact provides contracts for N26 Contact APIs.
+package contact
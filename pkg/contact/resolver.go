@@ -0,0 +1,102 @@
+package contact
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
+
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+// Transaction is a transaction.Transaction with the name of its contact.
+type Transaction struct {
+	transaction.Transaction
+
+	ContactName string `json:"contactName" csv:"contactName"`
+}
+
+// Resolver resolves smart contact IDs of transactions to the contact names.
+//
+// The contacts are fetched from the Finder on the first lookup and cached until Reset is called.
+type Resolver struct {
+	finder Finder
+	names  map[uuid.UUID]string
+
+	mu sync.Mutex
+}
+
+func (r *Resolver) load(ctx context.Context) (map[uuid.UUID]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.names != nil {
+		return r.names, nil
+	}
+
+	contacts, err := r.finder.FindAllContacts(ctx)
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not find contacts")
+	}
+
+	names := make(map[uuid.UUID]string, len(contacts))
+
+	for _, c := range contacts {
+		names[c.ID] = c.Name
+	}
+
+	r.names = names
+
+	return names, nil
+}
+
+// Name resolves the name of a contact. An empty string is returned if the contact is unknown.
+func (r *Resolver) Name(ctx context.Context, id uuid.UUID) (string, error) {
+	names, err := r.load(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return names[id], nil
+}
+
+// Enrich resolves the contact names of the transactions. If the contact of a transaction is unknown, the partner
+// name of the transaction is used.
+func (r *Resolver) Enrich(ctx context.Context, transactions []transaction.Transaction) ([]Transaction, error) {
+	names, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Transaction, 0, len(transactions))
+
+	for _, t := range transactions {
+		name, ok := names[t.SmartContactID]
+		if !ok {
+			name = t.PartnerName
+		}
+
+		result = append(result, Transaction{
+			Transaction: t,
+			ContactName: name,
+		})
+	}
+
+	return result, nil
+}
+
+// Reset clears the cached contacts, the next lookup fetches them again.
+func (r *Resolver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.names = nil
+}
+
+// NewResolver initiates a new Resolver.
+func NewResolver(finder Finder) *Resolver {
+	return &Resolver{
+		finder: finder,
+	}
+}
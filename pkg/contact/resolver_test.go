@@ -0,0 +1,115 @@
+package contact_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/contact"
+	contactMock "github.com/nhatthm/n26api/pkg/testkit/contact"
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+func TestResolver_Name(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockService   contactMock.ServiceMocker
+		expectedName  string
+		expectedError string
+	}{
+		{
+			scenario: "could not find contacts",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("FindAllContacts", context.Background()).
+					Return(nil, errors.New("find error"))
+			}),
+			expectedError: "could not find contacts: find error",
+		},
+		{
+			scenario: "unknown contact",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("FindAllContacts", context.Background()).
+					Return([]contact.Contact{{ID: uuid.New(), Name: "Jane Doe"}}, nil)
+			}),
+		},
+		{
+			scenario: "known contact",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("FindAllContacts", context.Background()).
+					Return([]contact.Contact{{ID: id, Name: "John Doe"}}, nil)
+			}),
+			expectedName: "John Doe",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			r := contact.NewResolver(tc.mockService(t))
+
+			name, err := r.Name(context.Background(), id)
+
+			assert.Equal(t, tc.expectedName, name)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestResolver_Enrich(t *testing.T) {
+	t.Parallel()
+
+	id1 := uuid.New()
+	id2 := uuid.New()
+	contactID := uuid.New()
+
+	s := contactMock.MockService(func(s *contactMock.Service) {
+		// The contacts are fetched only once.
+		s.On("FindAllContacts", context.Background()).
+			Return([]contact.Contact{{ID: contactID, Name: "John Doe"}}, nil).
+			Once()
+	})(t)
+
+	r := contact.NewResolver(s)
+
+	transactions := []transaction.Transaction{
+		{ID: id1, SmartContactID: contactID, PartnerName: "JOHN DOE"},
+		{ID: id2, PartnerName: "ACME GmbH"},
+	}
+
+	expected := []contact.Transaction{
+		{Transaction: transactions[0], ContactName: "John Doe"},
+		{Transaction: transactions[1], ContactName: "ACME GmbH"},
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := r.Enrich(context.Background(), transactions)
+
+		assert.Equal(t, expected, result)
+		assert.NoError(t, err)
+	}
+
+	r.Reset()
+
+	s.On("FindAllContacts", context.Background()).
+		Return(nil, errors.New("find error")).
+		Once()
+
+	result, err := r.Enrich(context.Background(), transactions)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "could not find contacts: find error")
+}
@@ -0,0 +1,26 @@
+package contact
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// AccountTypeSEPA is the account type of the contacts in SEPA area.
+const AccountTypeSEPA = "sepa"
+
+// Finder is a service to find n26 contacts.
+type Finder interface {
+	// FindAllContacts finds all saved contacts.
+	FindAllContacts(ctx context.Context) ([]Contact, error)
+}
+
+// Service is a service to manage n26 contacts.
+type Service interface {
+	Finder
+
+	// CreateContact saves a new contact.
+	CreateContact(ctx context.Context, name string, account Account) (*Contact, error)
+	// DeleteContact deletes a saved contact.
+	DeleteContact(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,2 @@
+// Package device provides contracts for N26 Paired Device APIs.
+package device
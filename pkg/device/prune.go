@@ -0,0 +1,33 @@
+package device
+
+import (
+	"context"
+
+	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
+)
+
+// PruneDevices unpairs all the devices except the one to keep, usually the device of the current client. It stops at
+// the first failure and returns the devices that are unpaired so far.
+func PruneDevices(ctx context.Context, s Service, keep uuid.UUID) ([]Device, error) {
+	devices, err := s.FindAllDevices(ctx)
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not find devices")
+	}
+
+	pruned := make([]Device, 0, len(devices))
+
+	for _, d := range devices {
+		if d.DeviceToken == keep {
+			continue
+		}
+
+		if err := s.UnpairDevice(ctx, d.DeviceToken); err != nil {
+			return pruned, ctxd.WrapError(ctx, err, "could not prune devices", "device_token", d.DeviceToken)
+		}
+
+		pruned = append(pruned, d)
+	}
+
+	return pruned, nil
+}
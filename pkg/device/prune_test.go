@@ -0,0 +1,91 @@
+package device_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/device"
+	deviceMock "github.com/nhatthm/n26api/pkg/testkit/device"
+)
+
+func TestPruneDevices(t *testing.T) {
+	t.Parallel()
+
+	own := device.Device{DeviceToken: uuid.New(), Name: "n26api"}
+	phone := device.Device{DeviceToken: uuid.New(), Name: "iPhone"}
+	stale := device.Device{DeviceToken: uuid.New(), Name: "n26api"}
+
+	testCases := []struct {
+		scenario       string
+		mockService    deviceMock.ServiceMocker
+		expectedResult []device.Device
+		expectedError  string
+	}{
+		{
+			scenario: "could not find devices",
+			mockService: deviceMock.MockService(func(s *deviceMock.Service) {
+				s.On("FindAllDevices", context.Background()).
+					Return(nil, errors.New("find error"))
+			}),
+			expectedError: "could not find devices: find error",
+		},
+		{
+			scenario: "nothing to prune",
+			mockService: deviceMock.MockService(func(s *deviceMock.Service) {
+				s.On("FindAllDevices", context.Background()).
+					Return([]device.Device{own}, nil)
+			}),
+			expectedResult: []device.Device{},
+		},
+		{
+			scenario: "could not unpair device",
+			mockService: deviceMock.MockService(func(s *deviceMock.Service) {
+				s.On("FindAllDevices", context.Background()).
+					Return([]device.Device{phone, own, stale}, nil)
+
+				s.On("UnpairDevice", context.Background(), phone.DeviceToken).
+					Return(nil)
+
+				s.On("UnpairDevice", context.Background(), stale.DeviceToken).
+					Return(errors.New("unpair error"))
+			}),
+			expectedResult: []device.Device{phone},
+			expectedError:  "could not prune devices: unpair error",
+		},
+		{
+			scenario: "success",
+			mockService: deviceMock.MockService(func(s *deviceMock.Service) {
+				s.On("FindAllDevices", context.Background()).
+					Return([]device.Device{phone, own, stale}, nil)
+
+				s.On("UnpairDevice", context.Background(), phone.DeviceToken).
+					Return(nil)
+
+				s.On("UnpairDevice", context.Background(), stale.DeviceToken).
+					Return(nil)
+			}),
+			expectedResult: []device.Device{phone, stale},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := device.PruneDevices(context.Background(), tc.mockService(t), own.DeviceToken)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
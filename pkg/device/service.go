@@ -0,0 +1,21 @@
+package device
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Finder is a service to find n26 paired devices.
+type Finder interface {
+	// FindAllDevices finds all paired devices.
+	FindAllDevices(ctx context.Context) ([]Device, error)
+}
+
+// Service is a service to manage n26 paired devices.
+type Service interface {
+	Finder
+
+	// UnpairDevice unpairs a device.
+	UnpairDevice(ctx context.Context, deviceToken uuid.UUID) error
+}
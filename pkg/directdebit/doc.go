@@ -0,0 +1,2 @@
+// Package directdebit provides contracts for N26 SEPA Direct Debit APIs.
+package directdebit
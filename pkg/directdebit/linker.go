@@ -0,0 +1,131 @@
+package directdebit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bool64/ctxd"
+
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+// Transaction is a transaction.Transaction with its direct debit mandate.
+type Transaction struct {
+	transaction.Transaction
+
+	Mandate *Mandate `json:"mandate,omitempty" csv:"mandate"`
+}
+
+type mandateKey struct {
+	creditorID string
+	reference  string
+}
+
+type mandates struct {
+	byKey       map[mandateKey]Mandate
+	byReference map[string][]Mandate
+}
+
+func (m mandates) find(t transaction.Transaction) *Mandate {
+	if t.Type != TransactionType || t.MandateID == "" {
+		return nil
+	}
+
+	if t.CreditorIdentifier != "" {
+		if found, ok := m.byKey[mandateKey{creditorID: t.CreditorIdentifier, reference: t.MandateID}]; ok {
+			return &found
+		}
+
+		return nil
+	}
+
+	// Mandate references are only unique per creditor.
+	if found := m.byReference[t.MandateID]; len(found) == 1 {
+		return &found[0]
+	}
+
+	return nil
+}
+
+// Linker links direct debit transactions to their mandates by the mandate reference and the creditor ID.
+//
+// The mandates are fetched from the Finder on the first lookup and cached until Reset is called.
+type Linker struct {
+	finder   Finder
+	mandates *mandates
+
+	mu sync.Mutex
+}
+
+func (l *Linker) load(ctx context.Context) (*mandates, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.mandates != nil {
+		return l.mandates, nil
+	}
+
+	found, err := l.finder.FindAllMandates(ctx)
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not find mandates")
+	}
+
+	m := &mandates{
+		byKey:       make(map[mandateKey]Mandate, len(found)),
+		byReference: make(map[string][]Mandate, len(found)),
+	}
+
+	for _, mandate := range found {
+		m.byKey[mandateKey{creditorID: mandate.CreditorID, reference: mandate.MandateReference}] = mandate
+		m.byReference[mandate.MandateReference] = append(m.byReference[mandate.MandateReference], mandate)
+	}
+
+	l.mandates = m
+
+	return m, nil
+}
+
+// Mandate finds the mandate of a direct debit transaction. Nil is returned if the transaction is not a direct debit or
+// the mandate is unknown.
+func (l *Linker) Mandate(ctx context.Context, t transaction.Transaction) (*Mandate, error) {
+	m, err := l.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.find(t), nil
+}
+
+// Link finds the mandates of the direct debit transactions.
+func (l *Linker) Link(ctx context.Context, transactions []transaction.Transaction) ([]Transaction, error) {
+	m, err := l.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Transaction, 0, len(transactions))
+
+	for _, t := range transactions {
+		result = append(result, Transaction{
+			Transaction: t,
+			Mandate:     m.find(t),
+		})
+	}
+
+	return result, nil
+}
+
+// Reset clears the cached mandates, the next lookup fetches them again.
+func (l *Linker) Reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.mandates = nil
+}
+
+// NewLinker initiates a new Linker.
+func NewLinker(finder Finder) *Linker {
+	return &Linker{
+		finder: finder,
+	}
+}
@@ -0,0 +1,160 @@
+package directdebit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/directdebit"
+	directdebitMock "github.com/nhatthm/n26api/pkg/testkit/directdebit"
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+func TestLinker_Mandate(t *testing.T) {
+	t.Parallel()
+
+	gym := directdebit.Mandate{ID: uuid.New(), CreditorID: "DE98ZZZ09999999999", CreditorName: "Gym GmbH", MandateReference: "M-0001"}
+	telco := directdebit.Mandate{ID: uuid.New(), CreditorID: "DE02ZZZ01234567890", CreditorName: "Telco AG", MandateReference: "M-0001"}
+	power := directdebit.Mandate{ID: uuid.New(), CreditorID: "DE11ZZZ00000000001", CreditorName: "Power AG", MandateReference: "P-42"}
+
+	testCases := []struct {
+		scenario        string
+		mockService     directdebitMock.ServiceMocker
+		transaction     transaction.Transaction
+		expectedMandate *directdebit.Mandate
+		expectedError   string
+	}{
+		{
+			scenario: "could not find mandates",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return(nil, errors.New("find error"))
+			}),
+			transaction:   transaction.Transaction{Type: directdebit.TransactionType, MandateID: "P-42"},
+			expectedError: "could not find mandates: find error",
+		},
+		{
+			scenario: "not a direct debit",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return([]directdebit.Mandate{power}, nil)
+			}),
+			transaction: transaction.Transaction{Type: "PT", MandateID: "P-42"},
+		},
+		{
+			scenario: "no mandate reference",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return([]directdebit.Mandate{power}, nil)
+			}),
+			transaction: transaction.Transaction{Type: directdebit.TransactionType},
+		},
+		{
+			scenario: "unknown mandate",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return([]directdebit.Mandate{power}, nil)
+			}),
+			transaction: transaction.Transaction{Type: directdebit.TransactionType, MandateID: "X-1"},
+		},
+		{
+			scenario: "unknown creditor",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return([]directdebit.Mandate{power}, nil)
+			}),
+			transaction: transaction.Transaction{Type: directdebit.TransactionType, MandateID: "P-42", CreditorIdentifier: "DE00ZZZ00000000000"},
+		},
+		{
+			scenario: "by creditor and reference",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return([]directdebit.Mandate{gym, telco, power}, nil)
+			}),
+			transaction:     transaction.Transaction{Type: directdebit.TransactionType, MandateID: "M-0001", CreditorIdentifier: telco.CreditorID},
+			expectedMandate: &telco,
+		},
+		{
+			scenario: "by unique reference",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return([]directdebit.Mandate{gym, telco, power}, nil)
+			}),
+			transaction:     transaction.Transaction{Type: directdebit.TransactionType, MandateID: "P-42"},
+			expectedMandate: &power,
+		},
+		{
+			scenario: "by ambiguous reference",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return([]directdebit.Mandate{gym, telco, power}, nil)
+			}),
+			transaction: transaction.Transaction{Type: directdebit.TransactionType, MandateID: "M-0001"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			l := directdebit.NewLinker(tc.mockService(t))
+
+			mandate, err := l.Mandate(context.Background(), tc.transaction)
+
+			assert.Equal(t, tc.expectedMandate, mandate)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestLinker_Link(t *testing.T) {
+	t.Parallel()
+
+	mandate := directdebit.Mandate{ID: uuid.New(), CreditorID: "DE98ZZZ09999999999", CreditorName: "Gym GmbH", MandateReference: "M-0001"}
+
+	s := directdebitMock.MockService(func(s *directdebitMock.Service) {
+		// The mandates are fetched only once.
+		s.On("FindAllMandates", context.Background()).
+			Return([]directdebit.Mandate{mandate}, nil).
+			Once()
+	})(t)
+
+	l := directdebit.NewLinker(s)
+
+	transactions := []transaction.Transaction{
+		{ID: uuid.New(), Type: directdebit.TransactionType, MandateID: "M-0001", CreditorIdentifier: "DE98ZZZ09999999999"},
+		{ID: uuid.New(), Type: "PT"},
+	}
+
+	expected := []directdebit.Transaction{
+		{Transaction: transactions[0], Mandate: &mandate},
+		{Transaction: transactions[1]},
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := l.Link(context.Background(), transactions)
+
+		assert.Equal(t, expected, result)
+		assert.NoError(t, err)
+	}
+
+	l.Reset()
+
+	s.On("FindAllMandates", context.Background()).
+		Return(nil, errors.New("find error")).
+		Once()
+
+	result, err := l.Link(context.Background(), transactions)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "could not find mandates: find error")
+}
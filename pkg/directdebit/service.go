@@ -0,0 +1,24 @@
+package directdebit
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// TransactionType is the type of SEPA direct debit transactions.
+const TransactionType = "MDD"
+
+// Finder is a service to find n26 direct debit mandates.
+type Finder interface {
+	// FindAllMandates finds all SEPA direct debit mandates.
+	FindAllMandates(ctx context.Context) ([]Mandate, error)
+}
+
+// Service is a service to manage n26 direct debits.
+type Service interface {
+	Finder
+
+	// ReturnDirectDebit initiates a return of a direct debit transaction.
+	ReturnDirectDebit(ctx context.Context, transactionID uuid.UUID) error
+}
@@ -0,0 +1,2 @@
+// Package limits provides contracts for N26 Account Limits APIs.
+package limits
@@ -0,0 +1,32 @@
+package limits
+
+import (
+	"context"
+)
+
+const (
+	// LimitATMDaily is the daily limit of ATM withdrawals.
+	LimitATMDaily = "ATM_DAILY_ACCOUNT"
+	// LimitPOSDaily is the daily limit of card payments in stores.
+	LimitPOSDaily = "POS_DAILY_ACCOUNT"
+	// LimitECommerce is the daily limit of online card payments.
+	LimitECommerce = "E_COMMERCE_TRANSACTION"
+	// LimitCountryList is the list of countries where the card can be used.
+	LimitCountryList = "COUNTRY_LIST"
+)
+
+// Finder is a service to find n26 account limits.
+type Finder interface {
+	// FindAllLimits finds the daily limits and the country restrictions.
+	FindAllLimits(ctx context.Context) ([]Limit, error)
+}
+
+// Service is a service to manage n26 account limits.
+type Service interface {
+	Finder
+
+	// SetLimit sets the amount of a daily limit, for example LimitATMDaily.
+	SetLimit(ctx context.Context, limit string, amount float64) error
+	// SetCountryRestrictions sets the list of countries where the card can be used.
+	SetCountryRestrictions(ctx context.Context, countries []string) error
+}
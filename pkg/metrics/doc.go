@@ -0,0 +1,2 @@
+// Package metrics provides contracts for N26 client metrics.
+package metrics
@@ -0,0 +1,2 @@
+// Package expvar provides a metrics.Metrics that publishes the metrics with the standard expvar package.
+package expvar
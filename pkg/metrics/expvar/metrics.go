@@ -0,0 +1,119 @@
+package expvar
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/nhatthm/n26api/pkg/metrics"
+)
+
+// DefaultName is the default name of the published variable.
+const DefaultName = "n26api"
+
+// ErrAlreadyPublished indicates that a variable with the same name is already published.
+var ErrAlreadyPublished = errors.New("variable is already published")
+
+// publishMu serializes the check and the publication of the variables, expvar.Publish panics on a duplicate name.
+var publishMu sync.Mutex
+
+var _ metrics.Metrics = (*Metrics)(nil)
+
+// Metrics publishes the metrics as a map, for example:
+//
+//	{
+//	  "requests": {"GET /api/smrt/transactions 200": 2},
+//	  "request_seconds": {"GET /api/smrt/transactions 200": 0.42},
+//	  "logins": {"success": 1},
+//	  "refreshes": {"success": 3},
+//	  "mfa_waits": {"confirmed": 1},
+//	  "mfa_wait_seconds": {"confirmed": 5.2},
+//	  "paginations": {"FindAllTransactionsInRange": 1},
+//	  "pages": {"FindAllTransactionsInRange": 2}
+//	}
+//
+// The counters and the sums of the durations are published, the averages can be computed from them.
+type Metrics struct {
+	vars *expvar.Map
+
+	requests       *expvar.Map
+	requestSeconds *expvar.Map
+	logins         *expvar.Map
+	refreshes      *expvar.Map
+	mfaWaits       *expvar.Map
+	mfaWaitSeconds *expvar.Map
+	paginations    *expvar.Map
+	pages          *expvar.Map
+}
+
+// ObserveRequest observes a request to N26.
+func (m *Metrics) ObserveRequest(method, pattern string, statusCode int, duration time.Duration) {
+	key := fmt.Sprintf("%s %s %d", method, pattern, statusCode)
+
+	m.requests.Add(key, 1)
+	m.requestSeconds.AddFloat(key, duration.Seconds())
+}
+
+// ObserveLogin observes a login attempt.
+func (m *Metrics) ObserveLogin(outcome string) {
+	m.logins.Add(outcome, 1)
+}
+
+// ObserveRefresh observes a token refresh.
+func (m *Metrics) ObserveRefresh(outcome string) {
+	m.refreshes.Add(outcome, 1)
+}
+
+// ObserveMFAWait observes the wait for the confirmation of a login.
+func (m *Metrics) ObserveMFAWait(outcome string, duration time.Duration) {
+	m.mfaWaits.Add(outcome, 1)
+	m.mfaWaitSeconds.AddFloat(outcome, duration.Seconds())
+}
+
+// ObservePages observes the number of pages of a paginated operation.
+func (m *Metrics) ObservePages(operation string, pages int) {
+	m.paginations.Add(operation, 1)
+	m.pages.Add(operation, int64(pages))
+}
+
+// Map returns the published map.
+func (m *Metrics) Map() *expvar.Map {
+	return m.vars
+}
+
+// NewMetrics initiates a new Metrics and publishes it with the given name, for example DefaultName.
+func NewMetrics(name string) (*Metrics, error) {
+	publishMu.Lock()
+	defer publishMu.Unlock()
+
+	if expvar.Get(name) != nil {
+		return nil, fmt.Errorf("could not publish metrics %s: %w", name, ErrAlreadyPublished)
+	}
+
+	m := &Metrics{
+		vars:           new(expvar.Map).Init(),
+		requests:       new(expvar.Map).Init(),
+		requestSeconds: new(expvar.Map).Init(),
+		logins:         new(expvar.Map).Init(),
+		refreshes:      new(expvar.Map).Init(),
+		mfaWaits:       new(expvar.Map).Init(),
+		mfaWaitSeconds: new(expvar.Map).Init(),
+		paginations:    new(expvar.Map).Init(),
+		pages:          new(expvar.Map).Init(),
+	}
+
+	m.vars.Set("requests", m.requests)
+	m.vars.Set("request_seconds", m.requestSeconds)
+	m.vars.Set("logins", m.logins)
+	m.vars.Set("refreshes", m.refreshes)
+	m.vars.Set("mfa_waits", m.mfaWaits)
+	m.vars.Set("mfa_wait_seconds", m.mfaWaitSeconds)
+	m.vars.Set("paginations", m.paginations)
+	m.vars.Set("pages", m.pages)
+
+	expvar.Publish(name, m.vars)
+
+	return m, nil
+}
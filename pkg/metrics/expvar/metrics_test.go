@@ -0,0 +1,92 @@
+package expvar_test
+
+import (
+	"errors"
+	"expvar"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api/pkg/metrics"
+	n26expvar "github.com/nhatthm/n26api/pkg/metrics/expvar"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	m, err := n26expvar.NewMetrics("n26api_test_metrics")
+	require.NoError(t, err)
+
+	m.ObserveRequest("GET", "/api/smrt/transactions", 200, 500*time.Millisecond)
+	m.ObserveRequest("GET", "/api/smrt/transactions", 200, time.Second)
+	m.ObserveLogin(metrics.OutcomeSuccess)
+	m.ObserveRefresh(metrics.OutcomeRejected)
+	m.ObserveMFAWait(metrics.OutcomeConfirmed, 5*time.Second)
+	m.ObservePages("FindAllTransactionsInRange", 3)
+	m.ObservePages("FindAllTransactionsInRange", 1)
+
+	expected := `{
+		"requests": {"GET /api/smrt/transactions 200": 2},
+		"request_seconds": {"GET /api/smrt/transactions 200": 1.5},
+		"logins": {"success": 1},
+		"refreshes": {"rejected": 1},
+		"mfa_waits": {"confirmed": 1},
+		"mfa_wait_seconds": {"confirmed": 5},
+		"paginations": {"FindAllTransactionsInRange": 2},
+		"pages": {"FindAllTransactionsInRange": 4}
+	}`
+
+	assert.JSONEq(t, expected, expvar.Get("n26api_test_metrics").String())
+	assert.Same(t, m.Map(), expvar.Get("n26api_test_metrics"))
+}
+
+func TestMetrics_AlreadyPublished(t *testing.T) {
+	t.Parallel()
+
+	expvar.NewInt("n26api_test_published")
+
+	m, err := n26expvar.NewMetrics("n26api_test_published")
+
+	assert.Nil(t, m)
+	assert.True(t, errors.Is(err, n26expvar.ErrAlreadyPublished))
+	assert.EqualError(t, err, "could not publish metrics n26api_test_published: variable is already published")
+}
+
+func TestMetrics_PublishConcurrently(t *testing.T) {
+	t.Parallel()
+
+	const workers = 10
+
+	var (
+		wg        sync.WaitGroup
+		published int32
+		rejected  int32
+	)
+
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			_, err := n26expvar.NewMetrics("n26api_test_concurrent")
+
+			switch {
+			case err == nil:
+				atomic.AddInt32(&published, 1)
+
+			case errors.Is(err, n26expvar.ErrAlreadyPublished):
+				atomic.AddInt32(&rejected, 1)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, int32(1), published)
+	assert.Equal(t, int32(workers-1), rejected)
+}
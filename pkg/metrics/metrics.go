@@ -0,0 +1,54 @@
+package metrics
+
+import "time"
+
+const (
+	// OutcomeSuccess is the outcome of a successful login or refresh.
+	OutcomeSuccess = "success"
+	// OutcomeWrongCredentials is the outcome of a login with wrong credentials.
+	OutcomeWrongCredentials = "wrong_credentials"
+	// OutcomeTooManyAttempts is the outcome of a login that is rejected because of too many attempts.
+	OutcomeTooManyAttempts = "too_many_attempts"
+	// OutcomeConfirmed is the outcome of an MFA wait that is confirmed on the paired device.
+	OutcomeConfirmed = "confirmed"
+	// OutcomeTimeout is the outcome of a login or an MFA wait that is not confirmed in time.
+	OutcomeTimeout = "timeout"
+	// OutcomeRejected is the outcome of a refresh that is rejected, the user has to log in again.
+	OutcomeRejected = "rejected"
+	// OutcomeError is the outcome of a login, a refresh or an MFA wait that fails for another reason.
+	OutcomeError = "error"
+)
+
+// Metrics observes the usage of N26.
+type Metrics interface {
+	// ObserveRequest observes a request to N26. The pattern is the operation, for example `/api/smrt/transactions`, and
+	// the status code is 0 if there is no response.
+	ObserveRequest(method, pattern string, statusCode int, duration time.Duration)
+	// ObserveLogin observes a login attempt.
+	ObserveLogin(outcome string)
+	// ObserveRefresh observes a token refresh.
+	ObserveRefresh(outcome string)
+	// ObserveMFAWait observes the wait for the confirmation of a login on the paired device.
+	ObserveMFAWait(outcome string, duration time.Duration)
+	// ObservePages observes the number of pages that are requested by a paginated operation.
+	ObservePages(operation string, pages int)
+}
+
+var _ Metrics = discard{}
+
+type discard struct{}
+
+func (discard) ObserveRequest(string, string, int, time.Duration) {}
+
+func (discard) ObserveLogin(string) {}
+
+func (discard) ObserveRefresh(string) {}
+
+func (discard) ObserveMFAWait(string, time.Duration) {}
+
+func (discard) ObservePages(string, int) {}
+
+// Discard returns a Metrics that observes nothing.
+func Discard() Metrics {
+	return discard{}
+}
@@ -0,0 +1,24 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/metrics"
+)
+
+func TestDiscard(t *testing.T) {
+	t.Parallel()
+
+	m := metrics.Discard()
+
+	assert.NotPanics(t, func() {
+		m.ObserveRequest("GET", "/api/me", 200, time.Second)
+		m.ObserveLogin(metrics.OutcomeSuccess)
+		m.ObserveRefresh(metrics.OutcomeSuccess)
+		m.ObserveMFAWait(metrics.OutcomeConfirmed, time.Second)
+		m.ObservePages("FindAllTransactionsInRange", 1)
+	})
+}
@@ -0,0 +1,2 @@
+// Package prometheus provides a metrics.Metrics that exports the metrics to Prometheus.
+package prometheus
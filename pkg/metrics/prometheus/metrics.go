@@ -0,0 +1,144 @@
+package prometheus
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/nhatthm/n26api/pkg/metrics"
+)
+
+// DefaultNamespace is the default namespace of the metrics.
+const DefaultNamespace = "n26api"
+
+// DefaultMFAWaitBuckets are the default buckets of the MFA wait histogram, in seconds. They go beyond the default MFA
+// timeout of the client, which is one minute.
+var DefaultMFAWaitBuckets = []float64{1, 5, 10, 15, 20, 30, 45, 60, 90, 120}
+
+var _ metrics.Metrics = (*Metrics)(nil)
+
+// Option configures Metrics.
+type Option func(c *config)
+
+type config struct {
+	namespace      string
+	buckets        []float64
+	mfaWaitBuckets []float64
+}
+
+// Metrics exports the metrics to Prometheus.
+type Metrics struct {
+	requests  *prometheus.HistogramVec
+	logins    *prometheus.CounterVec
+	refreshes *prometheus.CounterVec
+	mfaWaits  *prometheus.HistogramVec
+	pages     *prometheus.HistogramVec
+}
+
+// WithNamespace sets the namespace of the metrics, it is DefaultNamespace by default.
+func WithNamespace(namespace string) Option {
+	return func(c *config) {
+		c.namespace = namespace
+	}
+}
+
+// WithBuckets sets the buckets of the request duration histogram, in seconds. It is prometheus.DefBuckets by default.
+func WithBuckets(buckets ...float64) Option {
+	return func(c *config) {
+		c.buckets = buckets
+	}
+}
+
+// WithMFAWaitBuckets sets the buckets of the MFA wait histogram, in seconds. It is DefaultMFAWaitBuckets by default,
+// the last bucket should not be less than the MFA timeout of the client.
+func WithMFAWaitBuckets(buckets ...float64) Option {
+	return func(c *config) {
+		c.mfaWaitBuckets = buckets
+	}
+}
+
+// ObserveRequest observes a request to N26.
+func (m *Metrics) ObserveRequest(method, pattern string, statusCode int, duration time.Duration) {
+	m.requests.WithLabelValues(method, pattern, strconv.Itoa(statusCode)).Observe(duration.Seconds())
+}
+
+// ObserveLogin observes a login attempt.
+func (m *Metrics) ObserveLogin(outcome string) {
+	m.logins.WithLabelValues(outcome).Inc()
+}
+
+// ObserveRefresh observes a token refresh.
+func (m *Metrics) ObserveRefresh(outcome string) {
+	m.refreshes.WithLabelValues(outcome).Inc()
+}
+
+// ObserveMFAWait observes the wait for the confirmation of a login.
+func (m *Metrics) ObserveMFAWait(outcome string, duration time.Duration) {
+	m.mfaWaits.WithLabelValues(outcome).Observe(duration.Seconds())
+}
+
+// ObservePages observes the number of pages of a paginated operation.
+func (m *Metrics) ObservePages(operation string, pages int) {
+	m.pages.WithLabelValues(operation).Observe(float64(pages))
+}
+
+// NewMetrics initiates a new Metrics and registers its collectors.
+func NewMetrics(registerer prometheus.Registerer, options ...Option) (*Metrics, error) {
+	cfg := config{
+		namespace:      DefaultNamespace,
+		buckets:        prometheus.DefBuckets,
+		mfaWaitBuckets: DefaultMFAWaitBuckets,
+	}
+
+	for _, o := range options {
+		o(&cfg)
+	}
+
+	m := &Metrics{
+		requests: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the requests to N26 by method, pattern and status code.",
+			Buckets:   cfg.buckets,
+		}, []string{"method", "pattern", "status_code"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "logins_total",
+			Help:      "Number of login attempts by outcome.",
+		}, []string{"outcome"}),
+		refreshes: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: cfg.namespace,
+			Name:      "refreshes_total",
+			Help:      "Number of token refreshes by outcome.",
+		}, []string{"outcome"}),
+		mfaWaits: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace,
+			Name:      "mfa_wait_duration_seconds",
+			Help:      "Duration of the waits for the login confirmations by outcome.",
+			Buckets:   cfg.mfaWaitBuckets,
+		}, []string{"outcome"}),
+		pages: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: cfg.namespace,
+			Name:      "pages",
+			Help:      "Number of pages of the paginated operations.",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 8),
+		}, []string{"operation"}),
+	}
+
+	collectors := []prometheus.Collector{m.requests, m.logins, m.refreshes, m.mfaWaits, m.pages}
+
+	for i, c := range collectors {
+		if err := registerer.Register(c); err != nil {
+			// The metrics are registered all or nothing, so that NewMetrics can be called again.
+			for _, registered := range collectors[:i] {
+				registerer.Unregister(registered)
+			}
+
+			return nil, fmt.Errorf("could not register metrics: %w", err)
+		}
+	}
+
+	return m, nil
+}
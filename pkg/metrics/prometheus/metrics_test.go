@@ -0,0 +1,167 @@
+package prometheus_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api/pkg/metrics"
+	n26prometheus "github.com/nhatthm/n26api/pkg/metrics/prometheus"
+)
+
+func TestMetrics(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	m, err := n26prometheus.NewMetrics(registry,
+		n26prometheus.WithBuckets(1, 10),
+		n26prometheus.WithMFAWaitBuckets(10, 60),
+	)
+	require.NoError(t, err)
+
+	m.ObserveRequest("GET", "/api/smrt/transactions", 200, 500*time.Millisecond)
+	m.ObserveRequest("GET", "/api/smrt/transactions", 200, 2*time.Second)
+	m.ObserveLogin(metrics.OutcomeSuccess)
+	m.ObserveLogin(metrics.OutcomeWrongCredentials)
+	m.ObserveLogin(metrics.OutcomeSuccess)
+	m.ObserveRefresh(metrics.OutcomeRejected)
+	m.ObserveMFAWait(metrics.OutcomeConfirmed, 5*time.Second)
+	m.ObservePages("FindAllTransactionsInRange", 3)
+
+	expected := `
+# HELP n26api_logins_total Number of login attempts by outcome.
+# TYPE n26api_logins_total counter
+n26api_logins_total{outcome="success"} 2
+n26api_logins_total{outcome="wrong_credentials"} 1
+# HELP n26api_mfa_wait_duration_seconds Duration of the waits for the login confirmations by outcome.
+# TYPE n26api_mfa_wait_duration_seconds histogram
+n26api_mfa_wait_duration_seconds_bucket{outcome="confirmed",le="10"} 1
+n26api_mfa_wait_duration_seconds_bucket{outcome="confirmed",le="60"} 1
+n26api_mfa_wait_duration_seconds_bucket{outcome="confirmed",le="+Inf"} 1
+n26api_mfa_wait_duration_seconds_sum{outcome="confirmed"} 5
+n26api_mfa_wait_duration_seconds_count{outcome="confirmed"} 1
+# HELP n26api_refreshes_total Number of token refreshes by outcome.
+# TYPE n26api_refreshes_total counter
+n26api_refreshes_total{outcome="rejected"} 1
+# HELP n26api_request_duration_seconds Duration of the requests to N26 by method, pattern and status code.
+# TYPE n26api_request_duration_seconds histogram
+n26api_request_duration_seconds_bucket{method="GET",pattern="/api/smrt/transactions",status_code="200",le="1"} 1
+n26api_request_duration_seconds_bucket{method="GET",pattern="/api/smrt/transactions",status_code="200",le="10"} 2
+n26api_request_duration_seconds_bucket{method="GET",pattern="/api/smrt/transactions",status_code="200",le="+Inf"} 2
+n26api_request_duration_seconds_sum{method="GET",pattern="/api/smrt/transactions",status_code="200"} 2.5
+n26api_request_duration_seconds_count{method="GET",pattern="/api/smrt/transactions",status_code="200"} 2
+`
+
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"n26api_logins_total",
+		"n26api_mfa_wait_duration_seconds",
+		"n26api_refreshes_total",
+		"n26api_request_duration_seconds",
+	)
+	require.NoError(t, err)
+
+	count, err := testutil.GatherAndCount(registry, "n26api_pages")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, count)
+}
+
+func TestMetrics_Namespace(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	m, err := n26prometheus.NewMetrics(registry, n26prometheus.WithNamespace("bank"))
+	require.NoError(t, err)
+
+	m.ObserveLogin(metrics.OutcomeSuccess)
+
+	count, err := testutil.GatherAndCount(registry, "bank_logins_total")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, count)
+}
+
+func TestMetrics_AlreadyRegistered(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	_, err := n26prometheus.NewMetrics(registry)
+	require.NoError(t, err)
+
+	m, err := n26prometheus.NewMetrics(registry)
+
+	assert.Nil(t, m)
+	assert.ErrorContains(t, err, "could not register metrics: duplicate metrics collector registration attempted")
+}
+
+func TestMetrics_DefaultMFAWaitBuckets(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	m, err := n26prometheus.NewMetrics(registry)
+	require.NoError(t, err)
+
+	// The wait times out after one minute by default.
+	m.ObserveMFAWait(metrics.OutcomeTimeout, time.Minute)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	for _, f := range families {
+		if f.GetName() != "n26api_mfa_wait_duration_seconds" {
+			continue
+		}
+
+		buckets := f.GetMetric()[0].GetHistogram().GetBucket()
+
+		assert.Equal(t, len(n26prometheus.DefaultMFAWaitBuckets), len(buckets))
+		assert.Equal(t, uint64(1), buckets[len(buckets)-1].GetCumulativeCount())
+		assert.GreaterOrEqual(t, buckets[len(buckets)-1].GetUpperBound(), time.Minute.Seconds())
+
+		return
+	}
+
+	assert.Fail(t, "mfa wait histogram not found")
+}
+
+// failingRegisterer fails to register the collectors after the first ones.
+type failingRegisterer struct {
+	*prometheus.Registry
+
+	remaining int
+}
+
+func (r *failingRegisterer) Register(c prometheus.Collector) error {
+	if r.remaining == 0 {
+		return errors.New("registry is full")
+	}
+
+	r.remaining--
+
+	return r.Registry.Register(c)
+}
+
+func TestMetrics_RegisterFailure(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewRegistry()
+
+	m, err := n26prometheus.NewMetrics(&failingRegisterer{Registry: registry, remaining: 3})
+
+	assert.Nil(t, m)
+	assert.EqualError(t, err, "could not register metrics: registry is full")
+
+	// The collectors registered before the failure are unregistered.
+	_, err = n26prometheus.NewMetrics(registry)
+	require.NoError(t, err)
+}
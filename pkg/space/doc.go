@@ -0,0 +1,2 @@
+// Package space provides contracts for N26 Space APIs.
+package space
@@ -0,0 +1,13 @@
+package space
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Service is a service to manage n26 spaces.
+type Service interface {
+	// TransferBetweenSpaces moves money from a space to another.
+	TransferBetweenSpaces(ctx context.Context, fromSpaceID, toSpaceID uuid.UUID, amount float64) (*Transfer, error)
+}
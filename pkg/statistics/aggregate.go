@@ -0,0 +1,134 @@
+package statistics
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/nhatthm/n26api/pkg/transaction"
+	"github.com/nhatthm/n26api/pkg/util"
+)
+
+// MonthFormat is the layout of Month.Month.
+const MonthFormat = "2006-01"
+
+type amounts struct {
+	income  float64
+	expense float64
+}
+
+func (a *amounts) add(amount float64) {
+	if amount < 0 {
+		a.expense -= amount
+	} else {
+		a.income += amount
+	}
+}
+
+func (a amounts) values() (income float64, expense float64, total float64) {
+	return round(a.income), round(a.expense), round(a.income - a.expense)
+}
+
+// AggregateByCategory computes the category statistics of the transactions that are visible in a time period, the
+// same way N26 does. The items are sorted by category ID.
+func AggregateByCategory(from, to time.Time, transactions []transaction.Transaction) *Categories {
+	var all amounts
+
+	byCategory := make(map[string]*amounts)
+
+	for _, t := range inRange(from, to, transactions) {
+		a, ok := byCategory[t.Category]
+		if !ok {
+			a = &amounts{}
+			byCategory[t.Category] = a
+		}
+
+		a.add(t.Amount)
+		all.add(t.Amount)
+	}
+
+	result := &Categories{
+		From:  util.UnixTimestampMS(from),
+		To:    util.UnixTimestampMS(to),
+		Items: make([]Category, 0, len(byCategory)),
+	}
+
+	result.Income, result.Expense, result.Total = all.values()
+
+	for id, a := range byCategory {
+		item := Category{ID: id}
+		item.Income, item.Expense, item.Total = a.values()
+
+		result.Items = append(result.Items, item)
+	}
+
+	sort.Slice(result.Items, func(i, j int) bool {
+		return result.Items[i].ID < result.Items[j].ID
+	})
+
+	return result
+}
+
+// AggregateByMonth computes the monthly statistics of the transactions that are visible in a time period, the same way
+// N26 does. The transactions are grouped by month in the location of from and the items are sorted chronologically.
+func AggregateByMonth(from, to time.Time, transactions []transaction.Transaction) *Months {
+	var all amounts
+
+	byMonth := make(map[string]*amounts)
+
+	for _, t := range inRange(from, to, transactions) {
+		month := time.UnixMilli(t.VisibleTS).In(from.Location()).Format(MonthFormat)
+
+		a, ok := byMonth[month]
+		if !ok {
+			a = &amounts{}
+			byMonth[month] = a
+		}
+
+		a.add(t.Amount)
+		all.add(t.Amount)
+	}
+
+	result := &Months{
+		From:  util.UnixTimestampMS(from),
+		To:    util.UnixTimestampMS(to),
+		Items: make([]Month, 0, len(byMonth)),
+	}
+
+	result.Income, result.Expense, result.Total = all.values()
+
+	for month, a := range byMonth {
+		item := Month{Month: month}
+		item.Income, item.Expense, item.Total = a.values()
+
+		result.Items = append(result.Items, item)
+	}
+
+	// MonthFormat is sortable.
+	sort.Slice(result.Items, func(i, j int) bool {
+		return result.Items[i].Month < result.Items[j].Month
+	})
+
+	return result
+}
+
+func inRange(from, to time.Time, transactions []transaction.Transaction) []transaction.Transaction {
+	fromTS := util.UnixTimestampMS(from)
+	toTS := util.UnixTimestampMS(to)
+	result := make([]transaction.Transaction, 0, len(transactions))
+
+	for _, t := range transactions {
+		if t.VisibleTS < fromTS || t.VisibleTS > toTS {
+			continue
+		}
+
+		result = append(result, t)
+	}
+
+	return result
+}
+
+// round rounds an amount to cents.
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,86 @@
+package statistics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/statistics"
+	"github.com/nhatthm/n26api/pkg/testkit"
+	"github.com/nhatthm/n26api/pkg/transaction"
+	"github.com/nhatthm/n26api/pkg/util"
+)
+
+func TestAggregateByCategory(t *testing.T) {
+	t.Parallel()
+
+	f := testkit.NewStatisticsFixture()
+
+	assert.Equal(t, &f.Categories, statistics.AggregateByCategory(f.From, f.To, f.Transactions))
+}
+
+func TestAggregateByMonth(t *testing.T) {
+	t.Parallel()
+
+	f := testkit.NewStatisticsFixture()
+
+	assert.Equal(t, &f.Months, statistics.AggregateByMonth(f.From, f.To, f.Transactions))
+}
+
+func TestAggregateByMonth_Location(t *testing.T) {
+	t.Parallel()
+
+	f := testkit.NewStatisticsFixture()
+	result := statistics.AggregateByMonth(f.From.UTC(), f.To.UTC(), f.Transactions)
+
+	expected := []statistics.Month{
+		{Month: "2021-01", Income: 2500, Expense: 59.75, Total: 2440.25},
+		{Month: "2021-02", Expense: 59.99, Total: -59.99},
+		{Month: "2021-03", Income: 2500, Expense: 0.3, Total: 2499.7},
+	}
+
+	assert.Equal(t, expected, result.Items)
+}
+
+func TestAggregate_OutOfRange(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC)
+	transactions := []transaction.Transaction{
+		{Amount: -1, Category: "micro-v2-misc", VisibleTS: util.UnixTimestampMS(from.Add(-time.Millisecond))},
+		{Amount: -2, Category: "micro-v2-misc", VisibleTS: util.UnixTimestampMS(from)},
+		{Amount: -4, Category: "micro-v2-misc", VisibleTS: util.UnixTimestampMS(to)},
+		{Amount: -8, Category: "micro-v2-misc", VisibleTS: util.UnixTimestampMS(to.Add(time.Millisecond))},
+	}
+
+	expectedCategories := &statistics.Categories{
+		From:    util.UnixTimestampMS(from),
+		To:      util.UnixTimestampMS(to),
+		Expense: 6,
+		Total:   -6,
+		Items:   []statistics.Category{{ID: "micro-v2-misc", Expense: 6, Total: -6}},
+	}
+
+	expectedMonths := &statistics.Months{
+		From:    util.UnixTimestampMS(from),
+		To:      util.UnixTimestampMS(to),
+		Expense: 6,
+		Total:   -6,
+		Items:   []statistics.Month{{Month: "2021-01", Expense: 6, Total: -6}},
+	}
+
+	assert.Equal(t, expectedCategories, statistics.AggregateByCategory(from, to, transactions))
+	assert.Equal(t, expectedMonths, statistics.AggregateByMonth(from, to, transactions))
+}
+
+func TestAggregate_Empty(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, time.January, 31, 0, 0, 0, 0, time.UTC)
+
+	assert.Empty(t, statistics.AggregateByCategory(from, to, nil).Items)
+	assert.Empty(t, statistics.AggregateByMonth(from, to, nil).Items)
+}
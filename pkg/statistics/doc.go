@@ -0,0 +1,2 @@
+// Package statistics provides contracts for N26 Statistics APIs.
+package statistics
@@ -0,0 +1,14 @@
+package statistics
+
+import (
+	"context"
+	"time"
+)
+
+// Service is a service to get n26 spending statistics.
+type Service interface {
+	// CategoryStatistics gets income and expenses by category in a time period.
+	CategoryStatistics(ctx context.Context, from time.Time, to time.Time) (*Categories, error)
+	// MonthlyStatistics gets income and expenses by month in a time period.
+	MonthlyStatistics(ctx context.Context, from time.Time, to time.Time) (*Months, error)
+}
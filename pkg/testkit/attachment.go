@@ -0,0 +1,168 @@
+package testkit
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"go.nhat.io/httpmock/matcher"
+
+	"github.com/nhatthm/n26api/pkg/attachment"
+)
+
+var _ matcher.Matcher = (*multipartFileMatcher)(nil)
+
+// multipartFileMatcher matches a multipart/form-data body that has exactly one `file` part with the given file name,
+// content type and content.
+type multipartFileMatcher struct {
+	fileName    string
+	contentType string
+	content     []byte
+}
+
+// Expected returns the expectation.
+func (m multipartFileMatcher) Expected() string {
+	return fmt.Sprintf("multipart file %q (%s, %d bytes)", m.fileName, m.contentType, len(m.content))
+}
+
+// Match determines if the actual body is expected.
+func (m multipartFileMatcher) Match(actual interface{}) (bool, error) {
+	var body []byte
+
+	switch v := actual.(type) {
+	case string:
+		body = []byte(v)
+
+	case []byte:
+		body = v
+
+	default:
+		return false, fmt.Errorf("unexpected body type %T", actual) // nolint:goerr113
+	}
+
+	// The boundary is the first line of the body without the leading dashes.
+	line, err := bufio.NewReader(bytes.NewReader(body)).ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "--") {
+		return false, nil
+	}
+
+	r := multipart.NewReader(bytes.NewReader(body), strings.TrimSpace(strings.TrimPrefix(line, "--")))
+	files := 0
+
+	for {
+		part, err := r.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return false, nil
+		}
+
+		if part.FormName() != "file" {
+			continue
+		}
+
+		files++
+
+		if part.FileName() != m.fileName {
+			return false, nil
+		}
+
+		if contentType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type")); contentType != m.contentType { // nolint:errcheck
+			return false, nil
+		}
+
+		content, err := io.ReadAll(part)
+		if err != nil || !bytes.Equal(content, m.content) {
+			return false, nil
+		}
+	}
+
+	return files == 1, nil
+}
+
+func buildAttachmentsURI(transactionID uuid.UUID) string {
+	return fmt.Sprintf("/api/smrt/transactions/%s/attachments", transactionID.String())
+}
+
+func expectUploadAttachment(s *Server, transactionID uuid.UUID, fileName, contentType string, content []byte) Expectation {
+	return s.ExpectPost(buildAttachmentsURI(transactionID)).
+		WithHeader("Content-Type", matcher.RegexPattern(`^multipart/form-data; boundary=`)).
+		WithBody(multipartFileMatcher{
+			fileName:    fileName,
+			contentType: contentType,
+			content:     content,
+		})
+}
+
+// WithFindAllAttachments sets expectations for finding all attachments of a transaction.
+func WithFindAllAttachments(transactionID uuid.UUID, result []attachment.Attachment) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet(buildAttachmentsURI(transactionID)).ReturnJSON(result)
+	}
+}
+
+// WithFindAllAttachmentsFailureNotFound expects a request for finding all attachments of a transaction and returns a
+// not found error (404).
+func WithFindAllAttachmentsFailureNotFound(transactionID uuid.UUID) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet(buildAttachmentsURI(transactionID)).
+			ReturnCode(http.StatusNotFound).
+			ReturnJSON(notFoundError("Transaction not found"))
+	}
+}
+
+// WithUploadAttachmentSuccess expects a multipart request for uploading an attachment to a transaction and returns
+// the new attachment.
+func WithUploadAttachmentSuccess(transactionID uuid.UUID, fileName, contentType string, content []byte) ServerOption {
+	return func(s *Server) {
+		expectUploadAttachment(s, transactionID, fileName, contentType, content).
+			ReturnCode(http.StatusCreated).
+			Run(func(_ *http.Request) ([]byte, error) {
+				return json.Marshal(attachment.Attachment{
+					ID:            uuid.New(),
+					TransactionID: transactionID,
+					FileName:      fileName,
+					ContentType:   contentType,
+					Size:          int64(len(content)),
+				})
+			})
+	}
+}
+
+// WithUploadAttachmentFailureTooLarge expects a multipart request for uploading an attachment to a transaction and
+// returns a payload too large error (413).
+func WithUploadAttachmentFailureTooLarge(transactionID uuid.UUID, fileName, contentType string, content []byte) ServerOption {
+	return func(s *Server) {
+		expectUploadAttachment(s, transactionID, fileName, contentType, content).
+			ReturnCode(http.StatusRequestEntityTooLarge)
+	}
+}
+
+// WithDownloadAttachment sets expectations for downloading an attachment of a transaction.
+func WithDownloadAttachment(transactionID, attachmentID uuid.UUID, contentType string, content []byte) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet(fmt.Sprintf("%s/%s", buildAttachmentsURI(transactionID), attachmentID.String())).
+			ReturnHeader("Content-Type", contentType).
+			Return(content)
+	}
+}
+
+// WithDownloadAttachmentFailureNotFound expects a request for downloading an attachment of a transaction and returns a
+// not found error (404).
+func WithDownloadAttachmentFailureNotFound(transactionID, attachmentID uuid.UUID) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet(fmt.Sprintf("%s/%s", buildAttachmentsURI(transactionID), attachmentID.String())).
+			ReturnCode(http.StatusNotFound).
+			ReturnJSON(notFoundError("Attachment not found"))
+	}
+}
@@ -0,0 +1,2 @@
+// Package attachment provides functionalities for testing N26 Transaction Attachment APIs.
+package attachment
@@ -0,0 +1,87 @@
+package attachment
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/attachment"
+)
+
+// ServiceMocker is Service mocker.
+type ServiceMocker func(tb testing.TB) *Service
+
+// NoMockService is no mock Service.
+var NoMockService = MockService()
+
+var _ attachment.Service = (*Service)(nil)
+
+// Service is an attachment.Service.
+type Service struct {
+	mock.Mock
+}
+
+// FindAllAttachments satisfies attachment.Service.
+func (s *Service) FindAllAttachments(ctx context.Context, transactionID uuid.UUID) ([]attachment.Attachment, error) {
+	ret := s.Called(ctx, transactionID)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.([]attachment.Attachment), ret2
+}
+
+// UploadAttachment satisfies attachment.Service.
+func (s *Service) UploadAttachment(ctx context.Context, transactionID uuid.UUID, fileName string, r io.Reader) (*attachment.Attachment, error) {
+	ret := s.Called(ctx, transactionID, fileName, r)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.(*attachment.Attachment), ret2
+}
+
+// DownloadAttachment satisfies attachment.Service.
+//
+// Use mock.Call.Run to write the content to w.
+func (s *Service) DownloadAttachment(ctx context.Context, transactionID uuid.UUID, attachmentID uuid.UUID, w io.Writer) error {
+	return s.Called(ctx, transactionID, attachmentID, w).Error(0)
+}
+
+// mockService mocks attachment.Service interface.
+func mockService(mocks ...func(s *Service)) *Service {
+	s := &Service{}
+
+	for _, m := range mocks {
+		m(s)
+	}
+
+	return s
+}
+
+// MockService creates Service mock with cleanup to ensure all the expectations are met.
+func MockService(mocks ...func(s *Service)) ServiceMocker {
+	return func(tb testing.TB) *Service {
+		tb.Helper()
+
+		s := mockService(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, s.Mock.AssertExpectations(tb))
+		})
+
+		return s
+	}
+}
@@ -0,0 +1,180 @@
+package attachment_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/attachment"
+	attachmentMock "github.com/nhatthm/n26api/pkg/testkit/attachment"
+)
+
+func TestService_FindAllAttachments(t *testing.T) {
+	t.Parallel()
+
+	transactionID := uuid.New()
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario       string
+		mockService    attachmentMock.ServiceMocker
+		expectedResult []attachment.Attachment
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: attachmentMock.MockService(func(s *attachmentMock.Service) {
+				s.On("FindAllAttachments", context.Background(), transactionID).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: attachmentMock.MockService(func(s *attachmentMock.Service) {
+				s.On("FindAllAttachments", context.Background(), transactionID).
+					Return([]attachment.Attachment{{ID: id}}, nil)
+			}),
+			expectedResult: []attachment.Attachment{{ID: id}},
+		},
+		{
+			scenario: "error",
+			mockService: attachmentMock.MockService(func(s *attachmentMock.Service) {
+				s.On("FindAllAttachments", context.Background(), transactionID).
+					Return(nil, errors.New("find error"))
+			}),
+			expectedError: "find error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.mockService(t).FindAllAttachments(context.Background(), transactionID)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestService_UploadAttachment(t *testing.T) {
+	t.Parallel()
+
+	transactionID := uuid.New()
+	r := strings.NewReader("receipt")
+	uploaded := &attachment.Attachment{ID: uuid.New(), TransactionID: transactionID, FileName: "receipt.pdf"}
+
+	testCases := []struct {
+		scenario       string
+		mockService    attachmentMock.ServiceMocker
+		expectedResult *attachment.Attachment
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: attachmentMock.MockService(func(s *attachmentMock.Service) {
+				s.On("UploadAttachment", context.Background(), transactionID, "receipt.pdf", r).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: attachmentMock.MockService(func(s *attachmentMock.Service) {
+				s.On("UploadAttachment", context.Background(), transactionID, "receipt.pdf", r).
+					Return(uploaded, nil)
+			}),
+			expectedResult: uploaded,
+		},
+		{
+			scenario: "error",
+			mockService: attachmentMock.MockService(func(s *attachmentMock.Service) {
+				s.On("UploadAttachment", context.Background(), transactionID, "receipt.pdf", r).
+					Return(nil, errors.New("upload error"))
+			}),
+			expectedError: "upload error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.mockService(t).UploadAttachment(context.Background(), transactionID, "receipt.pdf", r)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestService_DownloadAttachment(t *testing.T) {
+	t.Parallel()
+
+	transactionID := uuid.New()
+	attachmentID := uuid.New()
+
+	testCases := []struct {
+		scenario        string
+		mockService     attachmentMock.ServiceMocker
+		expectedContent string
+		expectedError   string
+	}{
+		{
+			scenario: "success",
+			mockService: attachmentMock.MockService(func(s *attachmentMock.Service) {
+				s.On("DownloadAttachment", context.Background(), transactionID, attachmentID, mock.Anything).
+					Run(func(args mock.Arguments) {
+						_, _ = io.WriteString(args.Get(3).(io.Writer), "receipt")
+					}).
+					Return(nil)
+			}),
+			expectedContent: "receipt",
+		},
+		{
+			scenario: "error",
+			mockService: attachmentMock.MockService(func(s *attachmentMock.Service) {
+				s.On("DownloadAttachment", context.Background(), transactionID, attachmentID, mock.Anything).
+					Return(errors.New("download error"))
+			}),
+			expectedError: "download error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			buf := new(bytes.Buffer)
+			err := tc.mockService(t).DownloadAttachment(context.Background(), transactionID, attachmentID, buf)
+
+			assert.Equal(t, tc.expectedContent, buf.String())
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package testkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.nhat.io/httpmock"
+
+	"github.com/nhatthm/n26api/pkg/attachment"
+)
+
+func multipartBody(t *testing.T, parts ...func(w *multipart.Writer)) (string, []byte) {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	w := multipart.NewWriter(buf)
+
+	for _, p := range parts {
+		p(w)
+	}
+
+	require.NoError(t, w.Close())
+
+	return w.FormDataContentType(), buf.Bytes()
+}
+
+func multipartFile(t *testing.T, name, fileName, contentType string, content []byte) func(w *multipart.Writer) {
+	t.Helper()
+
+	return func(w *multipart.Writer) {
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, name, fileName))
+		h.Set("Content-Type", contentType)
+
+		part, err := w.CreatePart(h)
+		require.NoError(t, err)
+
+		_, err = part.Write(content)
+		require.NoError(t, err)
+	}
+}
+
+func TestMultipartFileMatcher(t *testing.T) {
+	t.Parallel()
+
+	m := multipartFileMatcher{
+		fileName:    "receipt.pdf",
+		contentType: "application/pdf",
+		content:     []byte("%PDF-1.4"),
+	}
+
+	testCases := []struct {
+		scenario      string
+		body          func(t *testing.T) interface{}
+		expected      bool
+		expectedError string
+	}{
+		{
+			scenario: "unexpected type",
+			body: func(*testing.T) interface{} {
+				return 42
+			},
+			expectedError: "unexpected body type int",
+		},
+		{
+			scenario: "not multipart",
+			body: func(*testing.T) interface{} {
+				return `{"fileName":"receipt.pdf"}`
+			},
+		},
+		{
+			scenario: "no file",
+			body: func(t *testing.T) interface{} {
+				_, body := multipartBody(t, func(w *multipart.Writer) {
+					require.NoError(t, w.WriteField("name", "receipt.pdf"))
+				})
+
+				return string(body)
+			},
+		},
+		{
+			scenario: "wrong file name",
+			body: func(t *testing.T) interface{} {
+				_, body := multipartBody(t, multipartFile(t, "file", "invoice.pdf", "application/pdf", []byte("%PDF-1.4")))
+
+				return string(body)
+			},
+		},
+		{
+			scenario: "wrong content type",
+			body: func(t *testing.T) interface{} {
+				_, body := multipartBody(t, multipartFile(t, "file", "receipt.pdf", "image/png", []byte("%PDF-1.4")))
+
+				return string(body)
+			},
+		},
+		{
+			scenario: "wrong content",
+			body: func(t *testing.T) interface{} {
+				_, body := multipartBody(t, multipartFile(t, "file", "receipt.pdf", "application/pdf", []byte("%PDF-1.7")))
+
+				return string(body)
+			},
+		},
+		{
+			scenario: "more than one file",
+			body: func(t *testing.T) interface{} {
+				f := multipartFile(t, "file", "receipt.pdf", "application/pdf", []byte("%PDF-1.4"))
+				_, body := multipartBody(t, f, f)
+
+				return string(body)
+			},
+		},
+		{
+			scenario: "match string",
+			body: func(t *testing.T) interface{} {
+				_, body := multipartBody(t,
+					func(w *multipart.Writer) {
+						require.NoError(t, w.WriteField("name", "receipt.pdf"))
+					},
+					multipartFile(t, "file", "receipt.pdf", "application/pdf", []byte("%PDF-1.4")),
+				)
+
+				return string(body)
+			},
+			expected: true,
+		},
+		{
+			scenario: "match bytes",
+			body: func(t *testing.T) interface{} {
+				_, body := multipartBody(t, multipartFile(t, "file", "receipt.pdf", "application/pdf", []byte("%PDF-1.4")))
+
+				return body
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			matched, err := m.Match(tc.body(t))
+
+			assert.Equal(t, tc.expected, matched)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+
+	assert.Equal(t, `multipart file "receipt.pdf" (application/pdf, 8 bytes)`, m.Expected())
+}
+
+func TestWithAttachments(t *testing.T) {
+	t.Parallel()
+
+	accessToken := uuid.New()
+	transactionID := uuid.New()
+	attachmentID := uuid.New()
+	content := []byte("%PDF-1.4")
+
+	s := MockEmptyServer(
+		func(s *Server) {
+			s.WithAccessToken(accessToken)
+		},
+		WithFindAllAttachments(transactionID, []attachment.Attachment{{ID: attachmentID, TransactionID: transactionID}}),
+		WithFindAllAttachmentsFailureNotFound(transactionID),
+		WithUploadAttachmentSuccess(transactionID, "receipt.pdf", "application/pdf", content),
+		WithUploadAttachmentFailureTooLarge(transactionID, "huge.pdf", "application/pdf", content),
+		WithDownloadAttachment(transactionID, attachmentID, "application/pdf", content),
+		WithDownloadAttachmentFailureNotFound(transactionID, attachmentID),
+	)(t)
+
+	requestURI := fmt.Sprintf("%s/api/smrt/transactions/%s/attachments", s.URL(), transactionID.String())
+	requestHeader := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", accessToken.String()),
+	}
+
+	// Find all attachments.
+	code, _, body, _ := httpmock.DoRequest(t, http.MethodGet, requestURI, requestHeader, nil)
+
+	expectedBody := fmt.Sprintf(`[{"id":%q,"transactionId":%q,"fileName":"","contentType":"","size":0}]`,
+		attachmentID.String(), transactionID.String(),
+	)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedBody, string(body))
+
+	// Find all attachments of a missing transaction.
+	code, _, _, _ = httpmock.DoRequest(t, http.MethodGet, requestURI, requestHeader, nil)
+
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// Upload an attachment.
+	contentType, payload := multipartBody(t, multipartFile(t, "file", "receipt.pdf", "application/pdf", content))
+
+	code, _, body, _ = httpmock.DoRequest(t, http.MethodPost, requestURI, map[string]string{
+		"Authorization": requestHeader["Authorization"],
+		"Content-Type":  contentType,
+	}, payload)
+
+	var uploaded attachment.Attachment
+
+	require.NoError(t, json.Unmarshal(body, &uploaded))
+
+	assert.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, transactionID, uploaded.TransactionID)
+	assert.Equal(t, "receipt.pdf", uploaded.FileName)
+	assert.Equal(t, "application/pdf", uploaded.ContentType)
+	assert.Equal(t, int64(len(content)), uploaded.Size)
+
+	// Upload a too large attachment.
+	contentType, payload = multipartBody(t, multipartFile(t, "file", "huge.pdf", "application/pdf", content))
+
+	code, _, _, _ = httpmock.DoRequest(t, http.MethodPost, requestURI, map[string]string{
+		"Authorization": requestHeader["Authorization"],
+		"Content-Type":  contentType,
+	}, payload)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, code)
+
+	// Download an attachment.
+	code, headers, body, _ := httpmock.DoRequest(t, http.MethodGet, requestURI+"/"+attachmentID.String(), requestHeader, nil)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "application/pdf", headers["Content-Type"])
+	assert.Equal(t, content, body)
+
+	// Download a missing attachment.
+	code, _, _, _ = httpmock.DoRequest(t, http.MethodGet, requestURI+"/"+attachmentID.String(), requestHeader, nil)
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
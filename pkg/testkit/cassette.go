@@ -0,0 +1,77 @@
+package testkit
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.nhat.io/httpmock/matcher"
+
+	"github.com/nhatthm/n26api/pkg/cassette"
+)
+
+var _ matcher.Matcher = (*cassetteURIMatcher)(nil)
+
+// skippedCassetteHeaders are the recorded response headers that are set by the server instead.
+var skippedCassetteHeaders = map[string]struct{}{
+	"Connection":        {},
+	"Content-Length":    {},
+	"Date":              {},
+	"Transfer-Encoding": {},
+}
+
+// cassetteURIMatcher matches a request URI with a recorded URI, see cassette.MatchURI.
+type cassetteURIMatcher string
+
+// Expected returns the expectation.
+func (m cassetteURIMatcher) Expected() string {
+	return string(m)
+}
+
+// Match determines if the actual request URI is expected.
+func (m cassetteURIMatcher) Match(actual interface{}) (bool, error) {
+	uri, ok := actual.(string)
+	if !ok {
+		return false, fmt.Errorf("unexpected request uri type %T", actual) // nolint:goerr113
+	}
+
+	return cassette.MatchURI(string(m), uri), nil
+}
+
+// WithCassette expects the interactions of a cassette in the recorded order and returns the recorded responses, see
+// cassette.Recorder. The authorization is not asserted because the tokens are scrubbed from the cassette, and the
+// scrubbed ids and query values of the recorded URIs match any value.
+func WithCassette(c *cassette.Cassette) ServerOption {
+	return func(s *Server) {
+		for _, i := range c.Interactions {
+			e := s.Server.Expect(i.Request.Method, cassetteURIMatcher(i.Request.URI)).
+				ReturnCode(i.Response.StatusCode)
+
+			for k := range i.Response.Header {
+				if _, ok := skippedCassetteHeaders[http.CanonicalHeaderKey(k)]; ok {
+					continue
+				}
+
+				e.ReturnHeader(k, i.Response.Header.Get(k))
+			}
+
+			e.Return([]byte(i.Response.Body))
+		}
+	}
+}
+
+// MockCassetteServer mocks a N26 API server that replays a cassette file, see WithCassette.
+func MockCassetteServer(path string, mocks ...ServerOption) ServerMocker {
+	return func(t TestingT) *Server {
+		c, err := cassette.Load(path)
+		if err != nil {
+			t.Errorf("%s", err.Error())
+			t.FailNow()
+		}
+
+		args := make([]ServerOption, 0, len(mocks)+1)
+		args = append(args, WithCassette(c))
+		args = append(args, mocks...)
+
+		return MockEmptyServer(args...)(t)
+	}
+}
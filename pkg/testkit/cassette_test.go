@@ -0,0 +1,93 @@
+package testkit_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api/pkg/cassette"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+// failingT records the error and stops the mock when it fails.
+type failingT struct {
+	testkit.TestingT
+
+	err string
+}
+
+func (t *failingT) Errorf(format string, args ...interface{}) {
+	t.err = fmt.Sprintf(format, args...)
+}
+
+func (t *failingT) FailNow() {
+	panic(t.err)
+}
+
+func TestMockCassetteServer(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	c := &cassette.Cassette{
+		Interactions: []cassette.Interaction{
+			{
+				Request: cassette.Request{Method: http.MethodGet, URI: "/api/me?full=true"},
+				Response: cassette.Response{
+					StatusCode: http.StatusOK,
+					Header: http.Header{
+						"Content-Type":   {"application/json;charset=UTF-8"},
+						"Content-Length": {"42"},
+					},
+					Body: cassette.Body(`{"id":"42"}`),
+				},
+			},
+			{
+				Request:  cassette.Request{Method: http.MethodDelete, URI: "/api/smrt/contacts/42"},
+				Response: cassette.Response{StatusCode: http.StatusNoContent},
+			},
+		},
+	}
+
+	require.NoError(t, c.Save(path))
+
+	s := testkit.MockCassetteServer(path)(t)
+
+	resp, err := http.Get(s.URL() + "/api/me?full=true") // nolint: noctx
+	require.NoError(t, err)
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json;charset=UTF-8", resp.Header.Get("Content-Type"))
+	assert.Equal(t, `{"id":"42"}`, string(body))
+
+	req, err := http.NewRequest(http.MethodDelete, s.URL()+"/api/smrt/contacts/42", nil) // nolint: noctx
+	require.NoError(t, err)
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close() // nolint: errcheck
+
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+}
+
+func TestMockCassetteServer_LoadError(t *testing.T) {
+	t.Parallel()
+
+	tt := &failingT{TestingT: t}
+
+	assert.Panics(t, func() {
+		testkit.MockCassetteServer(filepath.Join(t.TempDir(), "missing.json"))(tt)
+	})
+
+	assert.Contains(t, tt.err, "could not load cassette: open ")
+}
@@ -0,0 +1,21 @@
+package testkit
+
+import (
+	"github.com/nhatthm/n26api/pkg/category"
+)
+
+// WithFindAllCategories sets expectations for finding all categories.
+func WithFindAllCategories(result []category.Category) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet("/api/smrt/categories").ReturnJSON(result)
+	}
+}
+
+// WithFindAllCategoriesInLanguage sets expectations for finding all categories in a language.
+func WithFindAllCategoriesInLanguage(language string, result []category.Category) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet("/api/smrt/categories").
+			WithHeader("Accept-Language", language).
+			ReturnJSON(result)
+	}
+}
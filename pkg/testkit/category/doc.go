@@ -0,0 +1,2 @@
+// Package category provides functionalities for testing N26 Category APIs.
+package category
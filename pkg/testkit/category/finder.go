@@ -0,0 +1,64 @@
+package category
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/category"
+)
+
+// FinderMocker is Finder mocker.
+type FinderMocker func(tb testing.TB) *Finder
+
+// NoMockFinder is no mock Finder.
+var NoMockFinder = MockFinder()
+
+var _ category.Finder = (*Finder)(nil)
+
+// Finder is a category.Finder.
+type Finder struct {
+	mock.Mock
+}
+
+// FindAllCategories satisfies category.Finder.
+func (f *Finder) FindAllCategories(ctx context.Context) ([]category.Category, error) {
+	ret := f.Called(ctx)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.([]category.Category), ret2
+}
+
+// mockFinder mocks category.Finder interface.
+func mockFinder(mocks ...func(f *Finder)) *Finder {
+	f := &Finder{}
+
+	for _, m := range mocks {
+		m(f)
+	}
+
+	return f
+}
+
+// MockFinder creates Finder mock with cleanup to ensure all the expectations are met.
+func MockFinder(mocks ...func(f *Finder)) FinderMocker {
+	return func(tb testing.TB) *Finder {
+		tb.Helper()
+
+		f := mockFinder(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, f.Mock.AssertExpectations(tb))
+		})
+
+		return f
+	}
+}
@@ -0,0 +1,66 @@
+package category_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/category"
+	categoryMock "github.com/nhatthm/n26api/pkg/testkit/category"
+)
+
+func TestFinder_FindAllCategories(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario       string
+		mockFinder     categoryMock.FinderMocker
+		expectedResult []category.Category
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockFinder: categoryMock.MockFinder(func(f *categoryMock.Finder) {
+				f.On("FindAllCategories", context.Background()).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockFinder: categoryMock.MockFinder(func(f *categoryMock.Finder) {
+				f.On("FindAllCategories", context.Background()).
+					Return([]category.Category{{ID: "micro-v2-food-groceries", Name: "Food & Groceries"}}, nil)
+			}),
+			expectedResult: []category.Category{{ID: "micro-v2-food-groceries", Name: "Food & Groceries"}},
+		},
+		{
+			scenario: "error",
+			mockFinder: categoryMock.MockFinder(func(f *categoryMock.Finder) {
+				f.On("FindAllCategories", context.Background()).
+					Return(nil, errors.New("find error"))
+			}),
+			expectedError: "find error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			f := tc.mockFinder(t)
+
+			result, err := f.FindAllCategories(context.Background())
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
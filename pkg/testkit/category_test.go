@@ -0,0 +1,61 @@
+package testkit_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/category"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestWithFindAllCategories(t *testing.T) {
+	t.Parallel()
+
+	accessToken := uuid.New()
+	categories := []category.Category{{ID: "micro-v2-food-groceries", Name: "Groceries"}}
+
+	testCases := []struct {
+		scenario      string
+		mockServer    testkit.ServerMocker
+		requestHeader map[string]string
+	}{
+		{
+			scenario: "any language",
+			mockServer: testkit.MockEmptyServer(func(s *testkit.Server) {
+				s.WithAccessToken(accessToken)
+			}, testkit.WithFindAllCategories(categories)),
+			requestHeader: map[string]string{
+				"Authorization": fmt.Sprintf("Bearer %s", accessToken.String()),
+			},
+		},
+		{
+			scenario: "with language",
+			mockServer: testkit.MockEmptyServer(func(s *testkit.Server) {
+				s.WithAccessToken(accessToken)
+			}, testkit.WithFindAllCategoriesInLanguage("de", categories)),
+			requestHeader: map[string]string{
+				"Authorization":   fmt.Sprintf("Bearer %s", accessToken.String()),
+				"Accept-Language": "de",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			code, _, body, _ := request(t, s.URL(), http.MethodGet, "/api/smrt/categories", tc.requestHeader, nil)
+
+			expectedBody := `[{"id":"micro-v2-food-groceries","name":"Groceries"}]`
+
+			assert.Equal(t, http.StatusOK, code)
+			assert.Equal(t, expectedBody, string(body))
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package testkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/contact"
+)
+
+func expectCreateContact(s *Server, name string, account contact.Account) Expectation {
+	return s.ExpectPost("/api/smrt/contacts").
+		WithBodyJSON(api.ContactRequest{
+			Name:    name,
+			Account: account,
+		})
+}
+
+func expectDeleteContact(s *Server, id uuid.UUID) Expectation {
+	return s.ExpectDelete(fmt.Sprintf("/api/smrt/contacts/%s", id.String()))
+}
+
+// WithFindAllContacts sets expectations for finding all contacts.
+func WithFindAllContacts(result []contact.Contact) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet("/api/smrt/contacts").ReturnJSON(result)
+	}
+}
+
+// WithCreateContactSuccess expects a request for creating a contact and returns the new contact.
+func WithCreateContactSuccess(name string, account contact.Account) ServerOption {
+	return func(s *Server) {
+		expectCreateContact(s, name, account).
+			Run(func(_ *http.Request) ([]byte, error) {
+				return json.Marshal(contact.Contact{
+					ID:      uuid.New(),
+					UserID:  s.UserID(),
+					Name:    name,
+					Account: account,
+				})
+			})
+	}
+}
+
+// WithCreateContactFailureBadRequest expects a request for creating a contact and returns a bad request error (400).
+func WithCreateContactFailureBadRequest(name string, account contact.Account) ServerOption {
+	return func(s *Server) {
+		expectCreateContact(s, name, account).
+			ReturnCode(http.StatusBadRequest).
+			ReturnJSON(api.BadRequestError{
+				Status: http.StatusBadRequest,
+				Detail: "Invalid IBAN",
+				Type:   "invalid_request",
+				UserMessage: api.UserMessage{
+					Title:  "Invalid IBAN",
+					Detail: "Please check the IBAN and try again.",
+				},
+				Error:            "invalid_request",
+				ErrorDescription: "Invalid IBAN",
+			})
+	}
+}
+
+// WithDeleteContactSuccess expects a request for deleting a contact and returns a success.
+func WithDeleteContactSuccess(id uuid.UUID) ServerOption {
+	return func(s *Server) {
+		expectDeleteContact(s, id).
+			ReturnCode(http.StatusNoContent)
+	}
+}
+
+// WithDeleteContactFailureNotFound expects a request for deleting a contact and returns a not found error (404).
+func WithDeleteContactFailureNotFound(id uuid.UUID) ServerOption {
+	return func(s *Server) {
+		expectDeleteContact(s, id).
+			ReturnCode(http.StatusNotFound).
+			ReturnJSON(notFoundError("Contact not found"))
+	}
+}
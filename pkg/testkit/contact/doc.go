@@ -0,0 +1,2 @@
+// Package contact provides functionalities for testing N26 Contact APIs.
+package contact
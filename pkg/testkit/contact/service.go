@@ -0,0 +1,84 @@
+package contact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/contact"
+)
+
+// ServiceMocker is Service mocker.
+type ServiceMocker func(tb testing.TB) *Service
+
+// NoMockService is no mock Service.
+var NoMockService = MockService()
+
+var _ contact.Service = (*Service)(nil)
+
+// Service is a contact.Service.
+type Service struct {
+	mock.Mock
+}
+
+// FindAllContacts satisfies contact.Service.
+func (s *Service) FindAllContacts(ctx context.Context) ([]contact.Contact, error) {
+	ret := s.Called(ctx)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.([]contact.Contact), ret2
+}
+
+// CreateContact satisfies contact.Service.
+func (s *Service) CreateContact(ctx context.Context, name string, account contact.Account) (*contact.Contact, error) {
+	ret := s.Called(ctx, name, account)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.(*contact.Contact), ret2
+}
+
+// DeleteContact satisfies contact.Service.
+func (s *Service) DeleteContact(ctx context.Context, id uuid.UUID) error {
+	return s.Called(ctx, id).Error(0)
+}
+
+// mockService mocks contact.Service interface.
+func mockService(mocks ...func(s *Service)) *Service {
+	s := &Service{}
+
+	for _, m := range mocks {
+		m(s)
+	}
+
+	return s
+}
+
+// MockService creates Service mock with cleanup to ensure all the expectations are met.
+func MockService(mocks ...func(s *Service)) ServiceMocker {
+	return func(tb testing.TB) *Service {
+		tb.Helper()
+
+		s := mockService(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, s.Mock.AssertExpectations(tb))
+		})
+
+		return s
+	}
+}
@@ -0,0 +1,165 @@
+package contact_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/contact"
+	contactMock "github.com/nhatthm/n26api/pkg/testkit/contact"
+)
+
+func TestService_FindAllContacts(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario       string
+		mockService    contactMock.ServiceMocker
+		expectedResult []contact.Contact
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("FindAllContacts", context.Background()).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("FindAllContacts", context.Background()).
+					Return([]contact.Contact{{ID: id}}, nil)
+			}),
+			expectedResult: []contact.Contact{{ID: id}},
+		},
+		{
+			scenario: "error",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("FindAllContacts", context.Background()).
+					Return(nil, errors.New("find error"))
+			}),
+			expectedError: "find error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.mockService(t).FindAllContacts(context.Background())
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestService_CreateContact(t *testing.T) {
+	t.Parallel()
+
+	account := contact.Account{AccountType: contact.AccountTypeSEPA, Iban: "DE89370400440532013000"}
+	created := &contact.Contact{ID: uuid.New(), Name: "John Doe", Account: account}
+
+	testCases := []struct {
+		scenario       string
+		mockService    contactMock.ServiceMocker
+		expectedResult *contact.Contact
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("CreateContact", context.Background(), "John Doe", account).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("CreateContact", context.Background(), "John Doe", account).
+					Return(created, nil)
+			}),
+			expectedResult: created,
+		},
+		{
+			scenario: "error",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("CreateContact", context.Background(), "John Doe", account).
+					Return(nil, errors.New("create error"))
+			}),
+			expectedError: "create error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.mockService(t).CreateContact(context.Background(), "John Doe", account)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestService_DeleteContact(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockService   contactMock.ServiceMocker
+		expectedError string
+	}{
+		{
+			scenario: "success",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("DeleteContact", context.Background(), id).
+					Return(nil)
+			}),
+		},
+		{
+			scenario: "error",
+			mockService: contactMock.MockService(func(s *contactMock.Service) {
+				s.On("DeleteContact", context.Background(), id).
+					Return(errors.New("delete error"))
+			}),
+			expectedError: "delete error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mockService(t).DeleteContact(context.Background(), id)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
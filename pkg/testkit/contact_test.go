@@ -0,0 +1,76 @@
+package testkit_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api/pkg/contact"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestWithContacts(t *testing.T) {
+	t.Parallel()
+
+	accessToken := uuid.New()
+	id := uuid.New()
+	account := contact.Account{AccountType: contact.AccountTypeSEPA, Iban: "DE89370400440532013000"}
+
+	requestHeader := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", accessToken.String()),
+	}
+
+	s := testkit.MockEmptyServer(
+		func(s *testkit.Server) {
+			s.WithAccessToken(accessToken)
+		},
+		testkit.WithFindAllContacts([]contact.Contact{{ID: id, Name: "John Doe", Account: account}}),
+		testkit.WithCreateContactSuccess("Jane Doe", account),
+		testkit.WithCreateContactFailureBadRequest("ACME", account),
+		testkit.WithDeleteContactSuccess(id),
+		testkit.WithDeleteContactFailureNotFound(id),
+	)(t)
+
+	// Find all contacts.
+	code, _, body, _ := request(t, s.URL(), http.MethodGet, "/api/smrt/contacts", requestHeader, nil)
+
+	expectedBody := fmt.Sprintf(`[{"id":%q,"userId":"00000000-0000-0000-0000-000000000000","name":"John Doe","account":{"accountType":"sepa","iban":"DE89370400440532013000"}}]`, id.String())
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedBody, string(body))
+
+	// Create a contact.
+	code, _, body, _ = request(t, s.URL(), http.MethodPost, "/api/smrt/contacts", requestHeader,
+		[]byte(`{"name":"Jane Doe","account":{"accountType":"sepa","iban":"DE89370400440532013000"}}`),
+	)
+
+	var created contact.Contact
+
+	require.NoError(t, json.Unmarshal(body, &created))
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "Jane Doe", created.Name)
+	assert.Equal(t, s.UserID(), created.UserID)
+
+	// Create an invalid contact.
+	code, _, _, _ = request(t, s.URL(), http.MethodPost, "/api/smrt/contacts", requestHeader,
+		[]byte(`{"name":"ACME","account":{"accountType":"sepa","iban":"DE89370400440532013000"}}`),
+	)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Delete a contact.
+	code, _, _, _ = request(t, s.URL(), http.MethodDelete, "/api/smrt/contacts/"+id.String(), requestHeader, nil)
+
+	assert.Equal(t, http.StatusNoContent, code)
+
+	// Delete a missing contact.
+	code, _, _, _ = request(t, s.URL(), http.MethodDelete, "/api/smrt/contacts/"+id.String(), requestHeader, nil)
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -1,6 +1,7 @@
 package testkit
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -53,3 +54,47 @@ func MockCredentialsProvider(mocks ...func(p *CredentialsProvider)) CredentialsP
 		return p
 	}
 }
+
+// CredentialsProviderV2Mocker is CredentialsProviderV2 mocker.
+type CredentialsProviderV2Mocker func(tb testing.TB) *CredentialsProviderV2
+
+// NoMockCredentialsProviderV2 is no mock CredentialsProviderV2.
+var NoMockCredentialsProviderV2 = MockCredentialsProviderV2()
+
+// CredentialsProviderV2 is a CredentialsProviderV2.
+type CredentialsProviderV2 struct {
+	mock.Mock
+}
+
+// Credentials satisfies CredentialsProviderV2.
+func (c *CredentialsProviderV2) Credentials(ctx context.Context) (string, string, error) {
+	ret := c.Called(ctx)
+
+	return ret.String(0), ret.String(1), ret.Error(2)
+}
+
+// mockCredentialsProviderV2 mocks CredentialsProviderV2 interface.
+func mockCredentialsProviderV2(mocks ...func(p *CredentialsProviderV2)) *CredentialsProviderV2 {
+	p := &CredentialsProviderV2{}
+
+	for _, m := range mocks {
+		m(p)
+	}
+
+	return p
+}
+
+// MockCredentialsProviderV2 creates CredentialsProviderV2 mock with cleanup to ensure all the expectations are met.
+func MockCredentialsProviderV2(mocks ...func(p *CredentialsProviderV2)) CredentialsProviderV2Mocker {
+	return func(tb testing.TB) *CredentialsProviderV2 {
+		tb.Helper()
+
+		p := mockCredentialsProviderV2(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, p.Mock.AssertExpectations(tb))
+		})
+
+		return p
+	}
+}
@@ -1,6 +1,8 @@
 package testkit_test
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -20,3 +22,15 @@ func TestCredentialsProvider(t *testing.T) {
 	assert.Equal(t, expectedUsername, p.Username())
 	assert.Equal(t, expectedPassword, p.Password())
 }
+
+func TestCredentialsProviderV2(t *testing.T) {
+	p := testkit.MockCredentialsProviderV2(func(p *testkit.CredentialsProviderV2) {
+		p.On("Credentials", context.Background()).Return("username", "", errors.New("vault is locked"))
+	})(t)
+
+	username, password, err := p.Credentials(context.Background())
+
+	assert.Equal(t, "username", username)
+	assert.Empty(t, password)
+	assert.EqualError(t, err, "vault is locked")
+}
@@ -0,0 +1,54 @@
+package testkit
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/nhatthm/n26api/pkg/device"
+)
+
+func expectUnpairDevice(s *Server, deviceToken uuid.UUID) Expectation {
+	return s.ExpectDelete(fmt.Sprintf("/api/me/devices/%s", deviceToken.String()))
+}
+
+// WithFindAllDevices sets expectations for finding all paired devices.
+func WithFindAllDevices(result []device.Device) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet("/api/me/devices").ReturnJSON(result)
+	}
+}
+
+// WithUnpairDeviceSuccess expects a request for unpairing a device and returns a success.
+func WithUnpairDeviceSuccess(deviceToken uuid.UUID) ServerOption {
+	return func(s *Server) {
+		expectUnpairDevice(s, deviceToken).
+			ReturnCode(http.StatusNoContent)
+	}
+}
+
+// WithUnpairDeviceFailureNotFound expects a request for unpairing a device and returns a not found error (404).
+func WithUnpairDeviceFailureNotFound(deviceToken uuid.UUID) ServerOption {
+	return func(s *Server) {
+		expectUnpairDevice(s, deviceToken).
+			ReturnCode(http.StatusNotFound).
+			ReturnJSON(notFoundError("Device not found"))
+	}
+}
+
+// WithPruneDevices expects a request for finding all paired devices and the requests for unpairing all of them except
+// the device of the server, see Server.DeviceID. The device ID of the server must be set before applying this option.
+func WithPruneDevices(devices []device.Device) ServerOption {
+	return func(s *Server) {
+		WithFindAllDevices(devices)(s)
+
+		for _, d := range devices {
+			if d.DeviceToken == s.DeviceID() {
+				continue
+			}
+
+			WithUnpairDeviceSuccess(d.DeviceToken)(s)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+// Package device provides functionalities for testing N26 Paired Device APIs.
+package device
@@ -0,0 +1,70 @@
+package device
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/device"
+)
+
+// ServiceMocker is Service mocker.
+type ServiceMocker func(tb testing.TB) *Service
+
+// NoMockService is no mock Service.
+var NoMockService = MockService()
+
+var _ device.Service = (*Service)(nil)
+
+// Service is a device.Service.
+type Service struct {
+	mock.Mock
+}
+
+// FindAllDevices satisfies device.Service.
+func (s *Service) FindAllDevices(ctx context.Context) ([]device.Device, error) {
+	ret := s.Called(ctx)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.([]device.Device), ret2
+}
+
+// UnpairDevice satisfies device.Service.
+func (s *Service) UnpairDevice(ctx context.Context, deviceToken uuid.UUID) error {
+	return s.Called(ctx, deviceToken).Error(0)
+}
+
+// mockService mocks device.Service interface.
+func mockService(mocks ...func(s *Service)) *Service {
+	s := &Service{}
+
+	for _, m := range mocks {
+		m(s)
+	}
+
+	return s
+}
+
+// MockService creates Service mock with cleanup to ensure all the expectations are met.
+func MockService(mocks ...func(s *Service)) ServiceMocker {
+	return func(tb testing.TB) *Service {
+		tb.Helper()
+
+		s := mockService(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, s.Mock.AssertExpectations(tb))
+		})
+
+		return s
+	}
+}
@@ -0,0 +1,110 @@
+package device_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/device"
+	deviceMock "github.com/nhatthm/n26api/pkg/testkit/device"
+)
+
+func TestService_FindAllDevices(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario       string
+		mockService    deviceMock.ServiceMocker
+		expectedResult []device.Device
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: deviceMock.MockService(func(s *deviceMock.Service) {
+				s.On("FindAllDevices", context.Background()).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: deviceMock.MockService(func(s *deviceMock.Service) {
+				s.On("FindAllDevices", context.Background()).
+					Return([]device.Device{{DeviceToken: id}}, nil)
+			}),
+			expectedResult: []device.Device{{DeviceToken: id}},
+		},
+		{
+			scenario: "error",
+			mockService: deviceMock.MockService(func(s *deviceMock.Service) {
+				s.On("FindAllDevices", context.Background()).
+					Return(nil, errors.New("find error"))
+			}),
+			expectedError: "find error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.mockService(t).FindAllDevices(context.Background())
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestService_UnpairDevice(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockService   deviceMock.ServiceMocker
+		expectedError string
+	}{
+		{
+			scenario: "success",
+			mockService: deviceMock.MockService(func(s *deviceMock.Service) {
+				s.On("UnpairDevice", context.Background(), id).
+					Return(nil)
+			}),
+		},
+		{
+			scenario: "error",
+			mockService: deviceMock.MockService(func(s *deviceMock.Service) {
+				s.On("UnpairDevice", context.Background(), id).
+					Return(errors.New("unpair error"))
+			}),
+			expectedError: "unpair error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mockService(t).UnpairDevice(context.Background(), id)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
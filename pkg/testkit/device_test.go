@@ -0,0 +1,64 @@
+package testkit_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/device"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestWithDevices(t *testing.T) {
+	t.Parallel()
+
+	accessToken := uuid.New()
+	deviceID := uuid.New()
+	stale := uuid.New()
+
+	requestHeader := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", accessToken.String()),
+	}
+
+	devices := []device.Device{
+		{DeviceToken: deviceID, Name: "n26api"},
+		{DeviceToken: stale, Name: "n26api"},
+	}
+
+	s := testkit.MockEmptyServer(
+		func(s *testkit.Server) {
+			s.WithAccessToken(accessToken).
+				WithDeviceID(deviceID)
+		},
+		testkit.WithUnpairDeviceSuccess(stale),
+		testkit.WithUnpairDeviceFailureNotFound(stale),
+		testkit.WithPruneDevices(devices),
+	)(t)
+
+	requestURI := "/api/me/devices/" + stale.String()
+
+	// Unpair a device.
+	code, _, _, _ := request(t, s.URL(), http.MethodDelete, requestURI, requestHeader, nil)
+
+	assert.Equal(t, http.StatusNoContent, code)
+
+	// Unpair a missing device.
+	code, _, _, _ = request(t, s.URL(), http.MethodDelete, requestURI, requestHeader, nil)
+
+	assert.Equal(t, http.StatusNotFound, code)
+
+	// Prune devices, the device of the server is kept.
+	code, _, body, _ := request(t, s.URL(), http.MethodGet, "/api/me/devices", requestHeader, nil)
+
+	expectedBody := fmt.Sprintf(`[{"deviceToken":%q,"name":"n26api"},{"deviceToken":%q,"name":"n26api"}]`, deviceID.String(), stale.String())
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedBody, string(body))
+
+	code, _, _, _ = request(t, s.URL(), http.MethodDelete, requestURI, requestHeader, nil)
+
+	assert.Equal(t, http.StatusNoContent, code)
+}
@@ -0,0 +1,60 @@
+package testkit
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/directdebit"
+)
+
+func expectReturnDirectDebit(s *Server, transactionID uuid.UUID) Expectation {
+	return s.ExpectPost(fmt.Sprintf("/api/smrt/transactions/%s/return", transactionID.String()))
+}
+
+// WithFindAllMandates sets expectations for finding all direct debit mandates.
+func WithFindAllMandates(result []directdebit.Mandate) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet("/api/smrt/directdebits/mandates").ReturnJSON(result)
+	}
+}
+
+// WithReturnDirectDebitSuccess expects a request for returning a direct debit and returns a success.
+func WithReturnDirectDebitSuccess(transactionID uuid.UUID) ServerOption {
+	return func(s *Server) {
+		expectReturnDirectDebit(s, transactionID).
+			ReturnCode(http.StatusNoContent)
+	}
+}
+
+// WithReturnDirectDebitFailureNotReturnable expects a request for returning a direct debit and returns a bad request
+// error (400).
+func WithReturnDirectDebitFailureNotReturnable(transactionID uuid.UUID) ServerOption {
+	return func(s *Server) {
+		expectReturnDirectDebit(s, transactionID).
+			ReturnCode(http.StatusBadRequest).
+			ReturnJSON(api.BadRequestError{
+				Status: http.StatusBadRequest,
+				Detail: "Transaction is not returnable",
+				Type:   "invalid_request",
+				UserMessage: api.UserMessage{
+					Title:  "Return not possible",
+					Detail: "Only direct debits of the last 8 weeks can be returned.",
+				},
+				Error:            "invalid_request",
+				ErrorDescription: "Transaction is not returnable",
+			})
+	}
+}
+
+// WithReturnDirectDebitFailureNotFound expects a request for returning a direct debit and returns a not found error
+// (404).
+func WithReturnDirectDebitFailureNotFound(transactionID uuid.UUID) ServerOption {
+	return func(s *Server) {
+		expectReturnDirectDebit(s, transactionID).
+			ReturnCode(http.StatusNotFound).
+			ReturnJSON(notFoundError("Transaction not found"))
+	}
+}
@@ -0,0 +1,2 @@
+// Package directdebit provides functionalities for testing N26 SEPA Direct Debit APIs.
+package directdebit
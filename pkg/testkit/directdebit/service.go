@@ -0,0 +1,70 @@
+package directdebit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/directdebit"
+)
+
+// ServiceMocker is Service mocker.
+type ServiceMocker func(tb testing.TB) *Service
+
+// NoMockService is no mock Service.
+var NoMockService = MockService()
+
+var _ directdebit.Service = (*Service)(nil)
+
+// Service is a directdebit.Service.
+type Service struct {
+	mock.Mock
+}
+
+// FindAllMandates satisfies directdebit.Service.
+func (s *Service) FindAllMandates(ctx context.Context) ([]directdebit.Mandate, error) {
+	ret := s.Called(ctx)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.([]directdebit.Mandate), ret2
+}
+
+// ReturnDirectDebit satisfies directdebit.Service.
+func (s *Service) ReturnDirectDebit(ctx context.Context, transactionID uuid.UUID) error {
+	return s.Called(ctx, transactionID).Error(0)
+}
+
+// mockService mocks directdebit.Service interface.
+func mockService(mocks ...func(s *Service)) *Service {
+	s := &Service{}
+
+	for _, m := range mocks {
+		m(s)
+	}
+
+	return s
+}
+
+// MockService creates Service mock with cleanup to ensure all the expectations are met.
+func MockService(mocks ...func(s *Service)) ServiceMocker {
+	return func(tb testing.TB) *Service {
+		tb.Helper()
+
+		s := mockService(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, s.Mock.AssertExpectations(tb))
+		})
+
+		return s
+	}
+}
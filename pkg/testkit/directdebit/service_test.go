@@ -0,0 +1,110 @@
+package directdebit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/directdebit"
+	directdebitMock "github.com/nhatthm/n26api/pkg/testkit/directdebit"
+)
+
+func TestService_FindAllMandates(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario       string
+		mockService    directdebitMock.ServiceMocker
+		expectedResult []directdebit.Mandate
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return([]directdebit.Mandate{{ID: id}}, nil)
+			}),
+			expectedResult: []directdebit.Mandate{{ID: id}},
+		},
+		{
+			scenario: "error",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("FindAllMandates", context.Background()).
+					Return(nil, errors.New("find error"))
+			}),
+			expectedError: "find error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.mockService(t).FindAllMandates(context.Background())
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestService_ReturnDirectDebit(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockService   directdebitMock.ServiceMocker
+		expectedError string
+	}{
+		{
+			scenario: "success",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("ReturnDirectDebit", context.Background(), id).
+					Return(nil)
+			}),
+		},
+		{
+			scenario: "error",
+			mockService: directdebitMock.MockService(func(s *directdebitMock.Service) {
+				s.On("ReturnDirectDebit", context.Background(), id).
+					Return(errors.New("return error"))
+			}),
+			expectedError: "return error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mockService(t).ReturnDirectDebit(context.Background(), id)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
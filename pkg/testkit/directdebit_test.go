@@ -0,0 +1,62 @@
+package testkit_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/directdebit"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestWithDirectDebits(t *testing.T) {
+	t.Parallel()
+
+	accessToken := uuid.New()
+	mandateID := uuid.New()
+	transactionID := uuid.New()
+
+	requestHeader := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", accessToken.String()),
+	}
+
+	s := testkit.MockEmptyServer(
+		func(s *testkit.Server) {
+			s.WithAccessToken(accessToken)
+		},
+		testkit.WithFindAllMandates([]directdebit.Mandate{
+			{ID: mandateID, CreditorID: "DE98ZZZ09999999999", CreditorName: "Gym GmbH", MandateReference: "M-0001"},
+		}),
+		testkit.WithReturnDirectDebitSuccess(transactionID),
+		testkit.WithReturnDirectDebitFailureNotReturnable(transactionID),
+		testkit.WithReturnDirectDebitFailureNotFound(transactionID),
+	)(t)
+
+	// Find all mandates.
+	code, _, body, _ := request(t, s.URL(), http.MethodGet, "/api/smrt/directdebits/mandates", requestHeader, nil)
+
+	expectedBody := fmt.Sprintf(`[{"id":%q,"creditorId":"DE98ZZZ09999999999","creditorName":"Gym GmbH","mandateReference":"M-0001"}]`, mandateID.String())
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedBody, string(body))
+
+	requestURI := fmt.Sprintf("/api/smrt/transactions/%s/return", transactionID.String())
+
+	// Return a direct debit.
+	code, _, _, _ = request(t, s.URL(), http.MethodPost, requestURI, requestHeader, nil)
+
+	assert.Equal(t, http.StatusNoContent, code)
+
+	// Return a direct debit that is not returnable.
+	code, _, _, _ = request(t, s.URL(), http.MethodPost, requestURI, requestHeader, nil)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Return a missing transaction.
+	code, _, _, _ = request(t, s.URL(), http.MethodPost, requestURI, requestHeader, nil)
+
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,21 @@
+package testkit
+
+import (
+	"net/http"
+
+	"github.com/nhatthm/n26api/internal/api"
+)
+
+func notFoundError(detail string) api.NotFoundError {
+	return api.NotFoundError{
+		Status: http.StatusNotFound,
+		Detail: detail,
+		Type:   "not_found",
+		UserMessage: api.UserMessage{
+			Title:  "Oops!",
+			Detail: detail,
+		},
+		Error:            "not_found",
+		ErrorDescription: detail,
+	}
+}
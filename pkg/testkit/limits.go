@@ -0,0 +1,138 @@
+package testkit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"go.nhat.io/matcher/v2"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/limits"
+)
+
+func expectSetLimit(s *Server, req api.LimitRequest) Expectation {
+	return s.ExpectPost("/api/settings/account/limits").
+		WithHeader("device-token", func() matcher.Matcher {
+			return matcher.Exact(s.DeviceID().String())
+		}).
+		WithBodyJSON(req)
+}
+
+func expectConfirmSetLimit(s *Server, req api.LimitRequest) Expectation {
+	return expectSetLimit(s, req).
+		WithHeader("mfa-token", func() matcher.Matcher {
+			return matcher.Exact(s.MFAToken().String())
+		})
+}
+
+func returnLimitMFARequired(s *Server, renew bool) func(_ *http.Request) ([]byte, error) {
+	return func(_ *http.Request) ([]byte, error) {
+		if renew {
+			s.WithMFAToken(uuid.New())
+		}
+
+		return json.Marshal(api.RequiredMFATokenError{
+			UserMessage: api.UserMessage{
+				Title:  "A second authentication factor is required.",
+				Detail: "Please confirm the change on your paired device.",
+			},
+			MfaToken:         s.MFAToken().String(),
+			ErrorDescription: "MFA token is required",
+			Detail:           "MFA token is required",
+			Type:             "mfa_required",
+			Error:            "mfa_required",
+			Title:            "A second authentication factor is required.",
+			Message:          "Please confirm the change on your paired device.",
+			UserID:           s.UserID().String(),
+			Status:           http.StatusForbidden,
+		})
+	}
+}
+
+func withSetLimitSuccess(req api.LimitRequest) ServerOption {
+	return func(s *Server) {
+		expectSetLimit(s, req).
+			ReturnCode(http.StatusNoContent)
+	}
+}
+
+func withSetLimitFailureBadRequest(req api.LimitRequest) ServerOption {
+	return func(s *Server) {
+		expectSetLimit(s, req).
+			ReturnCode(http.StatusBadRequest).
+			ReturnJSON(api.BadRequestError{
+				Status: http.StatusBadRequest,
+				Detail: "Invalid limit",
+				Type:   "invalid_request",
+				UserMessage: api.UserMessage{
+					Title:  "Invalid limit",
+					Detail: "The limit is out of the allowed range.",
+				},
+				Error:            "invalid_request",
+				ErrorDescription: "Invalid limit",
+			})
+	}
+}
+
+func withSetLimitMFARequired(req api.LimitRequest, pending uint) ServerOption {
+	return func(s *Server) {
+		expectSetLimit(s, req).
+			ReturnCode(http.StatusForbidden).
+			Run(returnLimitMFARequired(s, true))
+
+		WithAuthMFAChallengeSuccess()(s)
+
+		if pending > 0 {
+			expectConfirmSetLimit(s, req).
+				Times(pending).
+				ReturnCode(http.StatusForbidden).
+				Run(returnLimitMFARequired(s, false))
+		}
+
+		expectConfirmSetLimit(s, req).
+			ReturnCode(http.StatusNoContent)
+	}
+}
+
+// WithFindAllLimits sets expectations for finding the daily limits and the country restrictions.
+func WithFindAllLimits(result []limits.Limit) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet("/api/settings/account/limits").ReturnJSON(result)
+	}
+}
+
+// WithSetLimitSuccess expects a request for setting a daily limit and returns a success.
+func WithSetLimitSuccess(limit string, amount float64) ServerOption {
+	return withSetLimitSuccess(api.LimitRequest{Limit: limit, Amount: amount})
+}
+
+// WithSetLimitFailureBadRequest expects a request for setting a daily limit and returns a bad request error (400).
+func WithSetLimitFailureBadRequest(limit string, amount float64) ServerOption {
+	return withSetLimitFailureBadRequest(api.LimitRequest{Limit: limit, Amount: amount})
+}
+
+// WithSetLimitMFARequired expects a request for setting a daily limit and returns a MFA required error (403). Then it
+// expects a MFA challenge and the retries of the request with the MFA token, the first `pending` retries are not
+// confirmed yet.
+func WithSetLimitMFARequired(limit string, amount float64, pending uint) ServerOption {
+	return withSetLimitMFARequired(api.LimitRequest{Limit: limit, Amount: amount}, pending)
+}
+
+// WithSetCountryRestrictionsSuccess expects a request for setting the country restrictions and returns a success.
+func WithSetCountryRestrictionsSuccess(countries []string) ServerOption {
+	return withSetLimitSuccess(api.LimitRequest{Limit: limits.LimitCountryList, CountryList: countries})
+}
+
+// WithSetCountryRestrictionsFailureBadRequest expects a request for setting the country restrictions and returns a
+// bad request error (400).
+func WithSetCountryRestrictionsFailureBadRequest(countries []string) ServerOption {
+	return withSetLimitFailureBadRequest(api.LimitRequest{Limit: limits.LimitCountryList, CountryList: countries})
+}
+
+// WithSetCountryRestrictionsMFARequired expects a request for setting the country restrictions and returns a MFA
+// required error (403). Then it expects a MFA challenge and the retries of the request with the MFA token, the first
+// `pending` retries are not confirmed yet.
+func WithSetCountryRestrictionsMFARequired(countries []string, pending uint) ServerOption {
+	return withSetLimitMFARequired(api.LimitRequest{Limit: limits.LimitCountryList, CountryList: countries}, pending)
+}
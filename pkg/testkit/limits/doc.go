@@ -0,0 +1,2 @@
+// Package limits provides functionalities for testing N26 Account Limits APIs.
+package limits
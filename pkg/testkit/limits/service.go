@@ -0,0 +1,74 @@
+package limits
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/limits"
+)
+
+// ServiceMocker is Service mocker.
+type ServiceMocker func(tb testing.TB) *Service
+
+// NoMockService is no mock Service.
+var NoMockService = MockService()
+
+var _ limits.Service = (*Service)(nil)
+
+// Service is a limits.Service.
+type Service struct {
+	mock.Mock
+}
+
+// FindAllLimits satisfies limits.Service.
+func (s *Service) FindAllLimits(ctx context.Context) ([]limits.Limit, error) {
+	ret := s.Called(ctx)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.([]limits.Limit), ret2
+}
+
+// SetLimit satisfies limits.Service.
+func (s *Service) SetLimit(ctx context.Context, limit string, amount float64) error {
+	return s.Called(ctx, limit, amount).Error(0)
+}
+
+// SetCountryRestrictions satisfies limits.Service.
+func (s *Service) SetCountryRestrictions(ctx context.Context, countries []string) error {
+	return s.Called(ctx, countries).Error(0)
+}
+
+// mockService mocks limits.Service interface.
+func mockService(mocks ...func(s *Service)) *Service {
+	s := &Service{}
+
+	for _, m := range mocks {
+		m(s)
+	}
+
+	return s
+}
+
+// MockService creates Service mock with cleanup to ensure all the expectations are met.
+func MockService(mocks ...func(s *Service)) ServiceMocker {
+	return func(tb testing.TB) *Service {
+		tb.Helper()
+
+		s := mockService(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, s.Mock.AssertExpectations(tb))
+		})
+
+		return s
+	}
+}
@@ -0,0 +1,146 @@
+package limits_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/limits"
+	limitsMock "github.com/nhatthm/n26api/pkg/testkit/limits"
+)
+
+func TestService_FindAllLimits(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario       string
+		mockService    limitsMock.ServiceMocker
+		expectedResult []limits.Limit
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: limitsMock.MockService(func(s *limitsMock.Service) {
+				s.On("FindAllLimits", context.Background()).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: limitsMock.MockService(func(s *limitsMock.Service) {
+				s.On("FindAllLimits", context.Background()).
+					Return([]limits.Limit{{Limit: limits.LimitATMDaily, Amount: 2500}}, nil)
+			}),
+			expectedResult: []limits.Limit{{Limit: limits.LimitATMDaily, Amount: 2500}},
+		},
+		{
+			scenario: "error",
+			mockService: limitsMock.MockService(func(s *limitsMock.Service) {
+				s.On("FindAllLimits", context.Background()).
+					Return(nil, errors.New("find error"))
+			}),
+			expectedError: "find error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.mockService(t).FindAllLimits(context.Background())
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestService_SetLimit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mockService   limitsMock.ServiceMocker
+		expectedError string
+	}{
+		{
+			scenario: "success",
+			mockService: limitsMock.MockService(func(s *limitsMock.Service) {
+				s.On("SetLimit", context.Background(), limits.LimitECommerce, 500.0).
+					Return(nil)
+			}),
+		},
+		{
+			scenario: "error",
+			mockService: limitsMock.MockService(func(s *limitsMock.Service) {
+				s.On("SetLimit", context.Background(), limits.LimitECommerce, 500.0).
+					Return(errors.New("set error"))
+			}),
+			expectedError: "set error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mockService(t).SetLimit(context.Background(), limits.LimitECommerce, 500)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestService_SetCountryRestrictions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mockService   limitsMock.ServiceMocker
+		expectedError string
+	}{
+		{
+			scenario: "success",
+			mockService: limitsMock.MockService(func(s *limitsMock.Service) {
+				s.On("SetCountryRestrictions", context.Background(), []string{"DE"}).
+					Return(nil)
+			}),
+		},
+		{
+			scenario: "error",
+			mockService: limitsMock.MockService(func(s *limitsMock.Service) {
+				s.On("SetCountryRestrictions", context.Background(), []string{"DE"}).
+					Return(errors.New("set error"))
+			}),
+			expectedError: "set error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mockService(t).SetCountryRestrictions(context.Background(), []string{"DE"})
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
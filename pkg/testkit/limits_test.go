@@ -0,0 +1,91 @@
+package testkit_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/limits"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestWithLimits(t *testing.T) {
+	t.Parallel()
+
+	accessToken := uuid.New()
+	deviceID := uuid.New()
+
+	s := testkit.MockEmptyServer(
+		func(s *testkit.Server) {
+			s.WithAccessToken(accessToken).
+				WithDeviceID(deviceID)
+		},
+		testkit.WithFindAllLimits([]limits.Limit{{Limit: limits.LimitATMDaily, Amount: 2500}}),
+		testkit.WithSetLimitSuccess(limits.LimitATMDaily, 1000),
+		testkit.WithSetLimitFailureBadRequest(limits.LimitATMDaily, 99999),
+		testkit.WithSetCountryRestrictionsMFARequired([]string{"DE"}, 1),
+	)(t)
+
+	requestHeader := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", accessToken.String()),
+		"device-token":  deviceID.String(),
+	}
+
+	// Find all limits.
+	code, _, body, _ := request(t, s.URL(), http.MethodGet, "/api/settings/account/limits", requestHeader, nil)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, `[{"limit":"ATM_DAILY_ACCOUNT","amount":2500}]`, string(body))
+
+	// Set a limit.
+	code, _, _, _ = request(t, s.URL(), http.MethodPost, "/api/settings/account/limits", requestHeader,
+		[]byte(`{"limit":"ATM_DAILY_ACCOUNT","amount":1000}`),
+	)
+
+	assert.Equal(t, http.StatusNoContent, code)
+
+	// Set an invalid limit.
+	code, _, _, _ = request(t, s.URL(), http.MethodPost, "/api/settings/account/limits", requestHeader,
+		[]byte(`{"limit":"ATM_DAILY_ACCOUNT","amount":99999}`),
+	)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Set the country restrictions, MFA is required.
+	code, _, body, _ = request(t, s.URL(), http.MethodPost, "/api/settings/account/limits", requestHeader,
+		[]byte(`{"limit":"COUNTRY_LIST","countryList":["DE"]}`),
+	)
+
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Contains(t, string(body), fmt.Sprintf(`"mfaToken":%q`, s.MFAToken().String()))
+
+	code, _, _, _ = request(t, s.URL(), http.MethodPost, "/api/mfa/challenge", map[string]string{
+		"Authorization": s.BasicAuthorization(),
+		"device-token":  deviceID.String(),
+	}, []byte(fmt.Sprintf(`{"challengeType":"oob","mfaToken":%q}`, s.MFAToken().String())))
+
+	assert.Equal(t, http.StatusCreated, code)
+
+	confirmHeader := map[string]string{
+		"Authorization": requestHeader["Authorization"],
+		"device-token":  deviceID.String(),
+		"mfa-token":     s.MFAToken().String(),
+	}
+
+	// Not confirmed yet.
+	code, _, _, _ = request(t, s.URL(), http.MethodPost, "/api/settings/account/limits", confirmHeader,
+		[]byte(`{"limit":"COUNTRY_LIST","countryList":["DE"]}`),
+	)
+
+	assert.Equal(t, http.StatusForbidden, code)
+
+	// Confirmed.
+	code, _, _, _ = request(t, s.URL(), http.MethodPost, "/api/settings/account/limits", confirmHeader,
+		[]byte(`{"limit":"COUNTRY_LIST","countryList":["DE"]}`),
+	)
+
+	assert.Equal(t, http.StatusNoContent, code)
+}
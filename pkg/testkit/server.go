@@ -50,6 +50,16 @@ func (s *Server) WithAuthAuthorization(username, password string) *Server {
 	return s
 }
 
+// WithUserID sets the userID.
+func (s *Server) WithUserID(userID uuid.UUID) *Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.userID = userID
+
+	return s
+}
+
 // WithDeviceID sets the deviceID.
 func (s *Server) WithDeviceID(deviceID uuid.UUID) *Server {
 	s.mu.Lock()
@@ -65,6 +65,16 @@ func TestServer_UserID(t *testing.T) {
 	assert.Equal(t, expected, s.UserID())
 }
 
+func TestServer_WithUserID(t *testing.T) {
+	t.Parallel()
+
+	expected := uuid.New()
+
+	s := (&Server{}).WithUserID(expected)
+
+	assert.Equal(t, expected, s.UserID())
+}
+
 func TestServer_WithDeviceID(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,225 @@
+package testkit
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"go.nhat.io/matcher/v2"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/space"
+	"github.com/nhatthm/n26api/pkg/util"
+)
+
+type spaceTransfer struct {
+	request  api.TransferRequest
+	transfer space.Transfer
+}
+
+// Spaces simulates the balances of the spaces for the transfers between them.
+//
+// The outcome of a transfer is decided when its server option is applied, against the balances after all the
+// previously applied transfers. The balances, see Spaces.Balance, change only when the server receives the transfer.
+type Spaces struct {
+	balances map[uuid.UUID]float64
+	planned  map[uuid.UUID]float64
+	locked   map[uuid.UUID]struct{}
+	keys     map[string]spaceTransfer
+
+	mu sync.Mutex
+}
+
+// WithBalance adds a space and sets its balance.
+func (s *Spaces) WithBalance(spaceID uuid.UUID, balance float64) *Spaces {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.balances[spaceID] = balance
+	s.planned[spaceID] = balance
+
+	return s
+}
+
+// WithLocked locks a space, money can not be moved from or to it.
+func (s *Spaces) WithLocked(spaceID uuid.UUID) *Spaces {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.locked[spaceID] = struct{}{}
+
+	return s
+}
+
+// Balance returns the balance of a space.
+func (s *Spaces) Balance(spaceID uuid.UUID) float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.balances[spaceID]
+}
+
+func (s *Spaces) move(from, to uuid.UUID, amount float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.balances[from] = roundCents(s.balances[from] - amount)
+	s.balances[to] = roundCents(s.balances[to] + amount)
+}
+
+// NewSpaces creates a new simulation of the spaces without any space.
+func NewSpaces() *Spaces {
+	return &Spaces{
+		balances: make(map[uuid.UUID]float64),
+		planned:  make(map[uuid.UUID]float64),
+		locked:   make(map[uuid.UUID]struct{}),
+		keys:     make(map[string]spaceTransfer),
+	}
+}
+
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+func spaceTransferError(detail, errorType string) api.BadRequestError {
+	return api.BadRequestError{
+		Status: http.StatusBadRequest,
+		Detail: detail,
+		Type:   errorType,
+		UserMessage: api.UserMessage{
+			Title:  "Oops!",
+			Detail: detail,
+		},
+		Error:            errorType,
+		ErrorDescription: detail,
+	}
+}
+
+func expectTransferBetweenSpaces(s *Server, idempotencyKey string, req api.TransferRequest) Expectation {
+	e := s.ExpectPost("/api/spaces/transaction").
+		WithBodyJSON(req)
+
+	if idempotencyKey != "" {
+		e.WithHeader("Idempotency-Key", matcher.Exact(idempotencyKey))
+	}
+
+	return e
+}
+
+// WithTransferBetweenSpaces expects a request for transferring money between spaces and simulates the outcome
+// against the spaces:
+//
+//   - a retry with an already used idempotency key returns the result of the first transfer (200) without moving the
+//     money again, or a conflict error (409) if the transfer is different.
+//   - an unknown space returns a not found error (404).
+//   - a locked space returns a locked error (423).
+//   - a balance lower than the amount returns an insufficient funds error (400).
+//   - otherwise, the money is moved when the request is received and the transfer is returned (200).
+//
+// An empty idempotency key matches any key and the transfer is not remembered for the retries.
+func WithTransferBetweenSpaces(spaces *Spaces, idempotencyKey string, fromSpaceID, toSpaceID uuid.UUID, amount float64) ServerOption {
+	req := api.TransferRequest{
+		FromSpaceID: fromSpaceID.String(),
+		ToSpaceID:   toSpaceID.String(),
+		Amount:      amount,
+	}
+
+	return func(s *Server) {
+		spaces.mu.Lock()
+		defer spaces.mu.Unlock()
+
+		e := expectTransferBetweenSpaces(s, idempotencyKey, req)
+
+		if previous, ok := spaces.keys[idempotencyKey]; ok && idempotencyKey != "" {
+			if previous.request != req {
+				e.ReturnCode(http.StatusConflict).
+					ReturnJSON(api.ConflictError{
+						Status: http.StatusConflict,
+						Detail: "Idempotency key is used for another transfer",
+						Type:   "conflict",
+						UserMessage: api.UserMessage{
+							Title:  "Oops!",
+							Detail: "Idempotency key is used for another transfer",
+						},
+						Error:            "conflict",
+						ErrorDescription: "Idempotency key is used for another transfer",
+					})
+
+				return
+			}
+
+			e.ReturnJSON(previous.transfer)
+
+			return
+		}
+
+		_, fromFound := spaces.planned[fromSpaceID]
+		_, toFound := spaces.planned[toSpaceID]
+
+		if !fromFound || !toFound {
+			e.ReturnCode(http.StatusNotFound).
+				ReturnJSON(notFoundError("Space not found"))
+
+			return
+		}
+
+		_, fromLocked := spaces.locked[fromSpaceID]
+		_, toLocked := spaces.locked[toSpaceID]
+
+		if fromLocked || toLocked {
+			e.ReturnCode(http.StatusLocked).
+				ReturnJSON(api.LockedError{
+					Status: http.StatusLocked,
+					Detail: "Space is locked",
+					Type:   "space_locked",
+					UserMessage: api.UserMessage{
+						Title:  "Oops!",
+						Detail: "Space is locked",
+					},
+					Error:            "space_locked",
+					ErrorDescription: "Space is locked",
+				})
+
+			return
+		}
+
+		if amount <= 0 || fromSpaceID == toSpaceID {
+			e.ReturnCode(http.StatusBadRequest).
+				ReturnJSON(spaceTransferError("Invalid transfer", "invalid_request"))
+
+			return
+		}
+
+		if spaces.planned[fromSpaceID] < amount {
+			e.ReturnCode(http.StatusBadRequest).
+				ReturnJSON(spaceTransferError("Insufficient funds", "insufficient_funds"))
+
+			return
+		}
+
+		spaces.planned[fromSpaceID] = roundCents(spaces.planned[fromSpaceID] - amount)
+		spaces.planned[toSpaceID] = roundCents(spaces.planned[toSpaceID] + amount)
+
+		transfer := space.Transfer{
+			ID:           uuid.New(),
+			FromSpaceID:  fromSpaceID,
+			ToSpaceID:    toSpaceID,
+			Amount:       amount,
+			CurrencyCode: "EUR",
+			CreatedTS:    util.UnixTimestampMS(time.Now()),
+		}
+
+		if idempotencyKey != "" {
+			spaces.keys[idempotencyKey] = spaceTransfer{request: req, transfer: transfer}
+		}
+
+		e.Run(func(*http.Request) ([]byte, error) {
+			spaces.move(fromSpaceID, toSpaceID, amount)
+
+			return json.Marshal(transfer)
+		})
+	}
+}
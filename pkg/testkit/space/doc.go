@@ -0,0 +1,2 @@
+// Package space provides functionalities for testing N26 Space APIs.
+package space
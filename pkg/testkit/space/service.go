@@ -0,0 +1,65 @@
+package space
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/space"
+)
+
+// ServiceMocker is Service mocker.
+type ServiceMocker func(tb testing.TB) *Service
+
+// NoMockService is no mock Service.
+var NoMockService = MockService()
+
+var _ space.Service = (*Service)(nil)
+
+// Service is a space.Service.
+type Service struct {
+	mock.Mock
+}
+
+// TransferBetweenSpaces satisfies space.Service.
+func (s *Service) TransferBetweenSpaces(ctx context.Context, fromSpaceID, toSpaceID uuid.UUID, amount float64) (*space.Transfer, error) {
+	ret := s.Called(ctx, fromSpaceID, toSpaceID, amount)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.(*space.Transfer), ret2
+}
+
+// mockService mocks space.Service interface.
+func mockService(mocks ...func(s *Service)) *Service {
+	s := &Service{}
+
+	for _, m := range mocks {
+		m(s)
+	}
+
+	return s
+}
+
+// MockService creates Service mock with cleanup to ensure all the expectations are met.
+func MockService(mocks ...func(s *Service)) ServiceMocker {
+	return func(tb testing.TB) *Service {
+		tb.Helper()
+
+		s := mockService(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, s.Mock.AssertExpectations(tb))
+		})
+
+		return s
+	}
+}
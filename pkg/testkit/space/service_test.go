@@ -0,0 +1,69 @@
+package space_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/space"
+	spaceMock "github.com/nhatthm/n26api/pkg/testkit/space"
+)
+
+func TestService_TransferBetweenSpaces(t *testing.T) {
+	t.Parallel()
+
+	from := uuid.New()
+	to := uuid.New()
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario       string
+		mockService    spaceMock.ServiceMocker
+		expectedResult *space.Transfer
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: spaceMock.MockService(func(s *spaceMock.Service) {
+				s.On("TransferBetweenSpaces", context.Background(), from, to, 10.5).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: spaceMock.MockService(func(s *spaceMock.Service) {
+				s.On("TransferBetweenSpaces", context.Background(), from, to, 10.5).
+					Return(&space.Transfer{ID: id}, nil)
+			}),
+			expectedResult: &space.Transfer{ID: id},
+		},
+		{
+			scenario: "error",
+			mockService: spaceMock.MockService(func(s *spaceMock.Service) {
+				s.On("TransferBetweenSpaces", context.Background(), from, to, 10.5).
+					Return(nil, errors.New("transfer error"))
+			}),
+			expectedError: "transfer error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.mockService(t).TransferBetweenSpaces(context.Background(), from, to, 10.5)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
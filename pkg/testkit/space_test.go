@@ -0,0 +1,107 @@
+package testkit_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api/pkg/space"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestWithTransferBetweenSpaces(t *testing.T) {
+	t.Parallel()
+
+	accessToken := uuid.New()
+	main := uuid.New()
+	savings := uuid.New()
+	holidays := uuid.New()
+	missing := uuid.New()
+
+	spaces := testkit.NewSpaces().
+		WithBalance(main, 100).
+		WithBalance(savings, 10).
+		WithBalance(holidays, 0).
+		WithLocked(holidays)
+
+	s := testkit.MockEmptyServer(
+		func(s *testkit.Server) {
+			s.WithAccessToken(accessToken)
+		},
+		testkit.WithTransferBetweenSpaces(spaces, "key-1", main, savings, 70),
+		testkit.WithTransferBetweenSpaces(spaces, "key-1", main, savings, 70),
+		testkit.WithTransferBetweenSpaces(spaces, "key-1", main, savings, 10),
+		testkit.WithTransferBetweenSpaces(spaces, "key-2", main, savings, 70),
+		testkit.WithTransferBetweenSpaces(spaces, "key-3", main, holidays, 10),
+		testkit.WithTransferBetweenSpaces(spaces, "key-4", main, missing, 10),
+	)(t)
+
+	transfer := func(key string, from, to uuid.UUID, amount float64) (int, []byte) {
+		requestHeader := map[string]string{
+			"Authorization":   fmt.Sprintf("Bearer %s", accessToken.String()),
+			"Idempotency-Key": key,
+		}
+
+		body := fmt.Sprintf(`{"fromSpaceId":%q,"toSpaceId":%q,"amount":%v}`, from.String(), to.String(), amount)
+
+		code, _, resp, _ := request(t, s.URL(), http.MethodPost, "/api/spaces/transaction", requestHeader, []byte(body))
+
+		return code, resp
+	}
+
+	// The balances do not change until the transfer is received.
+	assert.Equal(t, 100.0, spaces.Balance(main))
+	assert.Equal(t, 10.0, spaces.Balance(savings))
+
+	code, body := transfer("key-1", main, savings, 70)
+
+	var first space.Transfer
+
+	require.Equal(t, http.StatusOK, code)
+	require.NoError(t, json.Unmarshal(body, &first))
+
+	assert.Equal(t, 30.0, spaces.Balance(main))
+	assert.Equal(t, 80.0, spaces.Balance(savings))
+
+	// Retry.
+	code, body = transfer("key-1", main, savings, 70)
+
+	var retry space.Transfer
+
+	require.Equal(t, http.StatusOK, code)
+	require.NoError(t, json.Unmarshal(body, &retry))
+
+	assert.Equal(t, first, retry)
+	assert.Equal(t, 30.0, spaces.Balance(main))
+	assert.Equal(t, 80.0, spaces.Balance(savings))
+
+	// Reused key.
+	code, _ = transfer("key-1", main, savings, 10)
+
+	assert.Equal(t, http.StatusConflict, code)
+
+	// Insufficient funds.
+	code, body = transfer("key-2", main, savings, 70)
+
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, string(body), `"error":"insufficient_funds"`)
+
+	// Locked.
+	code, _ = transfer("key-3", main, holidays, 10)
+
+	assert.Equal(t, http.StatusLocked, code)
+
+	// Not found.
+	code, _ = transfer("key-4", main, missing, 10)
+
+	assert.Equal(t, http.StatusNotFound, code)
+
+	assert.Equal(t, 30.0, spaces.Balance(main))
+	assert.Equal(t, 80.0, spaces.Balance(savings))
+	assert.Equal(t, 0.0, spaces.Balance(holidays))
+}
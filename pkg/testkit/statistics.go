@@ -0,0 +1,109 @@
+package testkit
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/nhatthm/n26api/pkg/statistics"
+	"github.com/nhatthm/n26api/pkg/transaction"
+	"github.com/nhatthm/n26api/pkg/util"
+)
+
+// StatisticsFixture is a set of transactions and the statistics N26 computes for them.
+type StatisticsFixture struct {
+	From         time.Time
+	To           time.Time
+	Transactions []transaction.Transaction
+	Categories   statistics.Categories
+	Months       statistics.Months
+}
+
+func buildStatisticsURI(kind string, from, to time.Time) string {
+	return fmt.Sprintf("/api/smrt/statistics/%s/%d/%d", kind, util.UnixTimestampMS(from), util.UnixTimestampMS(to))
+}
+
+// WithCategoryStatistics sets expectations for getting the category statistics in a time period.
+func WithCategoryStatistics(from, to time.Time, result statistics.Categories) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet(buildStatisticsURI("categories", from, to)).ReturnJSON(result)
+	}
+}
+
+// WithMonthlyStatistics sets expectations for getting the monthly statistics in a time period.
+func WithMonthlyStatistics(from, to time.Time, result statistics.Months) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet(buildStatisticsURI("months", from, to)).ReturnJSON(result)
+	}
+}
+
+// WithStatisticsFixture sets expectations for getting the category and monthly statistics, and finding all the
+// transactions of the fixture.
+func WithStatisticsFixture(f StatisticsFixture, pageSize int64) ServerOption {
+	return func(s *Server) {
+		WithCategoryStatistics(f.From, f.To, f.Categories)(s)
+		WithMonthlyStatistics(f.From, f.To, f.Months)(s)
+		WithFindAllTransactionsInRange(f.From, f.To, pageSize, f.Transactions)(s)
+	}
+}
+
+// NewStatisticsFixture creates a fixture of the first quarter of 2021 in CET.
+func NewStatisticsFixture() StatisticsFixture {
+	cet := time.FixedZone("CET", 60*60)
+	from := time.Date(2021, time.January, 1, 0, 0, 0, 0, cet)
+	to := time.Date(2021, time.March, 31, 23, 59, 59, int(999*time.Millisecond), cet)
+
+	tx := func(id string, amount float64, category string, visible time.Time) transaction.Transaction {
+		return transaction.Transaction{
+			ID:           uuid.MustParse(id),
+			Type:         "PT",
+			Amount:       amount,
+			CurrencyCode: "EUR",
+			VisibleTS:    util.UnixTimestampMS(visible),
+			Category:     category,
+			Nature:       "NORMAL",
+			CreatedTS:    util.UnixTimestampMS(visible),
+		}
+	}
+
+	return StatisticsFixture{
+		From: from,
+		To:   to,
+		Transactions: []transaction.Transaction{
+			tx("4b7bcd47-6f5c-4c8b-9d63-5d1c1f3a0c01", -12.50, "micro-v2-food-groceries", time.Date(2021, time.January, 5, 10, 0, 0, 0, cet)),
+			tx("4b7bcd47-6f5c-4c8b-9d63-5d1c1f3a0c02", 2500, "micro-v2-income", time.Date(2021, time.January, 15, 8, 0, 0, 0, cet)),
+			tx("4b7bcd47-6f5c-4c8b-9d63-5d1c1f3a0c03", -40, "micro-v2-shopping", time.Date(2021, time.January, 31, 23, 30, 0, 0, cet)),
+			// Still January in UTC.
+			tx("4b7bcd47-6f5c-4c8b-9d63-5d1c1f3a0c04", -7.25, "micro-v2-food-groceries", time.Date(2021, time.February, 1, 0, 30, 0, 0, cet)),
+			tx("4b7bcd47-6f5c-4c8b-9d63-5d1c1f3a0c05", -59.99, "micro-v2-shopping", time.Date(2021, time.February, 14, 18, 0, 0, 0, cet)),
+			tx("4b7bcd47-6f5c-4c8b-9d63-5d1c1f3a0c06", 2500, "micro-v2-income", time.Date(2021, time.March, 1, 8, 0, 0, 0, cet)),
+			tx("4b7bcd47-6f5c-4c8b-9d63-5d1c1f3a0c07", -0.10, "micro-v2-food-groceries", time.Date(2021, time.March, 3, 12, 0, 0, 0, cet)),
+			tx("4b7bcd47-6f5c-4c8b-9d63-5d1c1f3a0c08", -0.20, "micro-v2-food-groceries", time.Date(2021, time.March, 3, 12, 5, 0, 0, cet)),
+		},
+		Categories: statistics.Categories{
+			From:    util.UnixTimestampMS(from),
+			To:      util.UnixTimestampMS(to),
+			Income:  5000,
+			Expense: 120.04,
+			Total:   4879.96,
+			Items: []statistics.Category{
+				{ID: "micro-v2-food-groceries", Expense: 20.05, Total: -20.05},
+				{ID: "micro-v2-income", Income: 5000, Total: 5000},
+				{ID: "micro-v2-shopping", Expense: 99.99, Total: -99.99},
+			},
+		},
+		Months: statistics.Months{
+			From:    util.UnixTimestampMS(from),
+			To:      util.UnixTimestampMS(to),
+			Income:  5000,
+			Expense: 120.04,
+			Total:   4879.96,
+			Items: []statistics.Month{
+				{Month: "2021-01", Income: 2500, Expense: 52.5, Total: 2447.5},
+				{Month: "2021-02", Expense: 67.24, Total: -67.24},
+				{Month: "2021-03", Income: 2500, Expense: 0.3, Total: 2499.7},
+			},
+		},
+	}
+}
@@ -0,0 +1,2 @@
+// Package statistics provides functionalities for testing N26 Statistics APIs.
+package statistics
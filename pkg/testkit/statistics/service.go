@@ -0,0 +1,79 @@
+package statistics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/statistics"
+)
+
+// ServiceMocker is Service mocker.
+type ServiceMocker func(tb testing.TB) *Service
+
+// NoMockService is no mock Service.
+var NoMockService = MockService()
+
+var _ statistics.Service = (*Service)(nil)
+
+// Service is a statistics.Service.
+type Service struct {
+	mock.Mock
+}
+
+// CategoryStatistics satisfies statistics.Service.
+func (s *Service) CategoryStatistics(ctx context.Context, from time.Time, to time.Time) (*statistics.Categories, error) {
+	ret := s.Called(ctx, from, to)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.(*statistics.Categories), ret2
+}
+
+// MonthlyStatistics satisfies statistics.Service.
+func (s *Service) MonthlyStatistics(ctx context.Context, from time.Time, to time.Time) (*statistics.Months, error) {
+	ret := s.Called(ctx, from, to)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.(*statistics.Months), ret2
+}
+
+// mockService mocks statistics.Service interface.
+func mockService(mocks ...func(s *Service)) *Service {
+	s := &Service{}
+
+	for _, m := range mocks {
+		m(s)
+	}
+
+	return s
+}
+
+// MockService creates Service mock with cleanup to ensure all the expectations are met.
+func MockService(mocks ...func(s *Service)) ServiceMocker {
+	return func(tb testing.TB) *Service {
+		tb.Helper()
+
+		s := mockService(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, s.Mock.AssertExpectations(tb))
+		})
+
+		return s
+	}
+}
@@ -0,0 +1,123 @@
+package statistics_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/statistics"
+	statisticsMock "github.com/nhatthm/n26api/pkg/testkit/statistics"
+)
+
+func TestService_CategoryStatistics(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		scenario       string
+		mockService    statisticsMock.ServiceMocker
+		expectedResult *statistics.Categories
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: statisticsMock.MockService(func(s *statisticsMock.Service) {
+				s.On("CategoryStatistics", context.Background(), from, to).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: statisticsMock.MockService(func(s *statisticsMock.Service) {
+				s.On("CategoryStatistics", context.Background(), from, to).
+					Return(&statistics.Categories{Income: 42}, nil)
+			}),
+			expectedResult: &statistics.Categories{Income: 42},
+		},
+		{
+			scenario: "error",
+			mockService: statisticsMock.MockService(func(s *statisticsMock.Service) {
+				s.On("CategoryStatistics", context.Background(), from, to).
+					Return(nil, errors.New("statistics error"))
+			}),
+			expectedError: "statistics error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.mockService(t).CategoryStatistics(context.Background(), from, to)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestService_MonthlyStatistics(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2021, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		scenario       string
+		mockService    statisticsMock.ServiceMocker
+		expectedResult *statistics.Months
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: statisticsMock.MockService(func(s *statisticsMock.Service) {
+				s.On("MonthlyStatistics", context.Background(), from, to).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: statisticsMock.MockService(func(s *statisticsMock.Service) {
+				s.On("MonthlyStatistics", context.Background(), from, to).
+					Return(&statistics.Months{Income: 42}, nil)
+			}),
+			expectedResult: &statistics.Months{Income: 42},
+		},
+		{
+			scenario: "error",
+			mockService: statisticsMock.MockService(func(s *statisticsMock.Service) {
+				s.On("MonthlyStatistics", context.Background(), from, to).
+					Return(nil, errors.New("statistics error"))
+			}),
+			expectedError: "statistics error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			result, err := tc.mockService(t).MonthlyStatistics(context.Background(), from, to)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package testkit_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestWithStatisticsFixture(t *testing.T) {
+	t.Parallel()
+
+	accessToken := uuid.New()
+	f := testkit.NewStatisticsFixture()
+
+	requestHeader := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", accessToken.String()),
+	}
+
+	s := testkit.MockEmptyServer(
+		func(s *testkit.Server) {
+			s.WithAccessToken(accessToken)
+		},
+		testkit.WithStatisticsFixture(f, 10),
+	)(t)
+
+	// Category statistics.
+	code, _, body, _ := request(t, s.URL(), http.MethodGet, "/api/smrt/statistics/categories/1609455600000/1617231599999", requestHeader, nil)
+
+	expectedBody := `{"from":1609455600000,"to":1617231599999,"income":5000,"expense":120.04,"total":4879.96,"items":[{"id":"micro-v2-food-groceries","income":0,"expense":20.05,"total":-20.05},{"id":"micro-v2-income","income":5000,"expense":0,"total":5000},{"id":"micro-v2-shopping","income":0,"expense":99.99,"total":-99.99}]}`
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedBody, string(body))
+
+	// Monthly statistics.
+	code, _, body, _ = request(t, s.URL(), http.MethodGet, "/api/smrt/statistics/months/1609455600000/1617231599999", requestHeader, nil)
+
+	expectedBody = `{"from":1609455600000,"to":1617231599999,"income":5000,"expense":120.04,"total":4879.96,"items":[{"month":"2021-01","income":2500,"expense":52.5,"total":2447.5},{"month":"2021-02","income":0,"expense":67.24,"total":-67.24},{"month":"2021-03","income":2500,"expense":0.3,"total":2499.7}]}`
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedBody, string(body))
+
+	// Transactions.
+	code, _, body, _ = request(t, s.URL(), http.MethodGet, "/api/smrt/transactions?from=1609455600000&limit=10&to=1617231599999", requestHeader, nil)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, string(body), f.Transactions[7].ID.String())
+}
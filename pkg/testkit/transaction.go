@@ -1,10 +1,14 @@
 package testkit
 
 import (
+	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/nhatthm/n26api/internal/api"
 	"github.com/nhatthm/n26api/pkg/transaction"
 	"github.com/nhatthm/n26api/pkg/util"
@@ -89,3 +93,41 @@ func WithFindAllTransactionsInRange(
 		}
 	}
 }
+
+func buildSmrtTransactionURI(id uuid.UUID) string {
+	return fmt.Sprintf("/api/smrt/transactions/%s", id.String())
+}
+
+// WithGetTransaction sets expectations for getting a transaction.
+func WithGetTransaction(result transaction.Transaction) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet(buildSmrtTransactionURI(result.ID)).ReturnJSON(result)
+	}
+}
+
+// WithGetTransactionFailureNotFound sets expectations for getting a transaction and returns a not found error (404).
+func WithGetTransactionFailureNotFound(id uuid.UUID) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet(buildSmrtTransactionURI(id)).
+			ReturnCode(http.StatusNotFound).
+			ReturnJSON(notFoundError("Transaction not found"))
+	}
+}
+
+// WithSetTransactionMemoSuccess sets expectations for setting the memo of a transaction.
+func WithSetTransactionMemoSuccess(id uuid.UUID, memo string) ServerOption {
+	return func(s *Server) {
+		s.ExpectPut(buildSmrtTransactionURI(id) + "/memo").
+			WithBodyJSON(api.TransactionMemoRequest{Memo: memo}).
+			ReturnCode(http.StatusNoContent)
+	}
+}
+
+// WithSetTransactionTagsSuccess sets expectations for setting the tags of a transaction.
+func WithSetTransactionTagsSuccess(id uuid.UUID, tags []string) ServerOption {
+	return func(s *Server) {
+		s.ExpectPut(buildSmrtTransactionURI(id) + "/tags").
+			WithBodyJSON(api.TransactionTagsRequest{Tags: tags}).
+			ReturnCode(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,65 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+// GetterMocker is Getter mocker.
+type GetterMocker func(tb testing.TB) *Getter
+
+// NoMockGetter is no mock Getter.
+var NoMockGetter = MockGetter()
+
+var _ transaction.Getter = (*Getter)(nil)
+
+// Getter is a transaction.Getter.
+type Getter struct {
+	mock.Mock
+}
+
+// GetTransaction satisfies transaction.Getter.
+func (g *Getter) GetTransaction(ctx context.Context, id uuid.UUID) (*transaction.Transaction, error) {
+	ret := g.Called(ctx, id)
+
+	ret1 := ret.Get(0)
+	ret2 := ret.Error(1)
+
+	if ret1 == nil {
+		return nil, ret2
+	}
+
+	return ret1.(*transaction.Transaction), ret2
+}
+
+// mockGetter mocks transaction.Getter interface.
+func mockGetter(mocks ...func(g *Getter)) *Getter {
+	g := &Getter{}
+
+	for _, m := range mocks {
+		m(g)
+	}
+
+	return g
+}
+
+// MockGetter creates Getter mock with cleanup to ensure all the expectations are met.
+func MockGetter(mocks ...func(g *Getter)) GetterMocker {
+	return func(tb testing.TB) *Getter {
+		tb.Helper()
+
+		g := mockGetter(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, g.Mock.AssertExpectations(tb))
+		})
+
+		return g
+	}
+}
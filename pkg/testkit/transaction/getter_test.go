@@ -0,0 +1,69 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	transactionMock "github.com/nhatthm/n26api/pkg/testkit/transaction"
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+func TestGetter_GetTransaction(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario       string
+		mockGetter     transactionMock.GetterMocker
+		expectedResult *transaction.Transaction
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockGetter: transactionMock.MockGetter(func(g *transactionMock.Getter) {
+				g.On("GetTransaction", context.Background(), id).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockGetter: transactionMock.MockGetter(func(g *transactionMock.Getter) {
+				g.On("GetTransaction", context.Background(), id).
+					Return(&transaction.Transaction{ID: id}, nil)
+			}),
+			expectedResult: &transaction.Transaction{ID: id},
+		},
+		{
+			scenario: "error",
+			mockGetter: transactionMock.MockGetter(func(g *transactionMock.Getter) {
+				g.On("GetTransaction", context.Background(), id).
+					Return(nil, errors.New("get error"))
+			}),
+			expectedError: "get error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			g := tc.mockGetter(t)
+
+			result, err := g.GetTransaction(context.Background(), id)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
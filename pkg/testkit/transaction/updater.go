@@ -0,0 +1,61 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+// UpdaterMocker is Updater mocker.
+type UpdaterMocker func(tb testing.TB) *Updater
+
+// NoMockUpdater is no mock Updater.
+var NoMockUpdater = MockUpdater()
+
+var _ transaction.Updater = (*Updater)(nil)
+
+// Updater is a transaction.Updater.
+type Updater struct {
+	mock.Mock
+}
+
+// SetTransactionMemo satisfies transaction.Updater.
+func (u *Updater) SetTransactionMemo(ctx context.Context, id uuid.UUID, memo string) error {
+	return u.Called(ctx, id, memo).Error(0)
+}
+
+// SetTransactionTags satisfies transaction.Updater.
+func (u *Updater) SetTransactionTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	return u.Called(ctx, id, tags).Error(0)
+}
+
+// mockUpdater mocks transaction.Updater interface.
+func mockUpdater(mocks ...func(u *Updater)) *Updater {
+	u := &Updater{}
+
+	for _, m := range mocks {
+		m(u)
+	}
+
+	return u
+}
+
+// MockUpdater creates Updater mock with cleanup to ensure all the expectations are met.
+func MockUpdater(mocks ...func(u *Updater)) UpdaterMocker {
+	return func(tb testing.TB) *Updater {
+		tb.Helper()
+
+		u := mockUpdater(mocks...)
+
+		tb.Cleanup(func() {
+			assert.True(tb, u.Mock.AssertExpectations(tb))
+		})
+
+		return u
+	}
+}
@@ -0,0 +1,99 @@
+package transaction_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	transactionMock "github.com/nhatthm/n26api/pkg/testkit/transaction"
+)
+
+func TestUpdater_SetTransactionMemo(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockUpdater   transactionMock.UpdaterMocker
+		expectedError string
+	}{
+		{
+			scenario: "success",
+			mockUpdater: transactionMock.MockUpdater(func(u *transactionMock.Updater) {
+				u.On("SetTransactionMemo", context.Background(), id, "lunch").
+					Return(nil)
+			}),
+		},
+		{
+			scenario: "error",
+			mockUpdater: transactionMock.MockUpdater(func(u *transactionMock.Updater) {
+				u.On("SetTransactionMemo", context.Background(), id, "lunch").
+					Return(errors.New("memo error"))
+			}),
+			expectedError: "memo error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mockUpdater(t).SetTransactionMemo(context.Background(), id, "lunch")
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestUpdater_SetTransactionTags(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.New()
+	tags := []string{"business", "travel"}
+
+	testCases := []struct {
+		scenario      string
+		mockUpdater   transactionMock.UpdaterMocker
+		expectedError string
+	}{
+		{
+			scenario: "success",
+			mockUpdater: transactionMock.MockUpdater(func(u *transactionMock.Updater) {
+				u.On("SetTransactionTags", context.Background(), id, tags).
+					Return(nil)
+			}),
+		},
+		{
+			scenario: "error",
+			mockUpdater: transactionMock.MockUpdater(func(u *transactionMock.Updater) {
+				u.On("SetTransactionTags", context.Background(), id, tags).
+					Return(errors.New("tags error"))
+			}),
+			expectedError: "tags error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.mockUpdater(t).SetTransactionTags(context.Background(), id, tags)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package testkit
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
@@ -121,3 +122,41 @@ func TestWithFindAllTransactionsInRange(t *testing.T) {
 		})
 	}
 }
+
+func TestWithTransaction(t *testing.T) {
+	t.Parallel()
+
+	accessToken := uuid.New()
+	id := uuid.New()
+	missingID := uuid.New()
+
+	requestHeader := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", accessToken.String()),
+	}
+
+	s := MockEmptyServer(
+		func(s *Server) {
+			s.WithAccessToken(accessToken)
+		},
+		WithGetTransaction(transaction.Transaction{ID: id}),
+		WithGetTransactionFailureNotFound(missingID),
+		WithSetTransactionMemoSuccess(id, "lunch"),
+		WithSetTransactionTagsSuccess(id, []string{"business"}),
+	)(t)
+
+	code, _, _, _ := httpmock.DoRequest(t, http.MethodGet, s.URL()+"/api/smrt/transactions/"+id.String(), requestHeader, nil)
+
+	assert.Equal(t, http.StatusOK, code)
+
+	code, _, _, _ = httpmock.DoRequest(t, http.MethodGet, s.URL()+"/api/smrt/transactions/"+missingID.String(), requestHeader, nil)
+
+	assert.Equal(t, http.StatusNotFound, code)
+
+	code, _, _, _ = httpmock.DoRequest(t, http.MethodPut, s.URL()+"/api/smrt/transactions/"+id.String()+"/memo", requestHeader, []byte(`{"memo":"lunch"}`))
+
+	assert.Equal(t, http.StatusNoContent, code)
+
+	code, _, _, _ = httpmock.DoRequest(t, http.MethodPut, s.URL()+"/api/smrt/transactions/"+id.String()+"/tags", requestHeader, []byte(`{"tags":["business"]}`))
+
+	assert.Equal(t, http.StatusNoContent, code)
+}
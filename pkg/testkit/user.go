@@ -0,0 +1,21 @@
+package testkit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nhatthm/n26api/pkg/user"
+)
+
+// WithMe sets expectations for getting the profile of the current user. The user ID of the profile is always the
+// Server.UserID().
+func WithMe(profile user.Profile) ServerOption {
+	return func(s *Server) {
+		s.ExpectGet("/api/me?full=true").
+			Run(func(_ *http.Request) ([]byte, error) {
+				profile.Info.ID = s.UserID()
+
+				return json.Marshal(profile)
+			})
+	}
+}
@@ -0,0 +1,2 @@
+// Package user provides functionalities for testing N26 User APIs.
+package user
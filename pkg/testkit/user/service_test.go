@@ -0,0 +1,69 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	userMock "github.com/nhatthm/n26api/pkg/testkit/user"
+	"github.com/nhatthm/n26api/pkg/user"
+)
+
+func TestService_Me(t *testing.T) {
+	t.Parallel()
+
+	profile := &user.Profile{Info: user.Info{ID: uuid.New(), Email: "john.doe@example.com"}}
+
+	testCases := []struct {
+		scenario       string
+		mockService    userMock.ServiceMocker
+		expectedResult *user.Profile
+		expectedError  string
+	}{
+		{
+			scenario: "result is nil",
+			mockService: userMock.MockService(func(s *userMock.Service) {
+				s.On("Me", context.Background()).
+					Return(nil, nil)
+			}),
+		},
+		{
+			scenario: "result is not nil",
+			mockService: userMock.MockService(func(s *userMock.Service) {
+				s.On("Me", context.Background()).
+					Return(profile, nil)
+			}),
+			expectedResult: profile,
+		},
+		{
+			scenario: "error",
+			mockService: userMock.MockService(func(s *userMock.Service) {
+				s.On("Me", context.Background()).
+					Return(nil, errors.New("me error"))
+			}),
+			expectedError: "me error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockService(t)
+
+			result, err := s.Me(context.Background())
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package testkit_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api/pkg/testkit"
+	"github.com/nhatthm/n26api/pkg/user"
+)
+
+func TestWithMe(t *testing.T) {
+	t.Parallel()
+
+	accessToken := uuid.New()
+
+	s := testkit.MockEmptyServer(func(s *testkit.Server) {
+		s.WithAccessToken(accessToken)
+	}, testkit.WithMe(user.Profile{
+		Info: user.Info{ID: uuid.New(), Email: "john.doe@example.com"},
+	}))(t)
+
+	requestHeader := map[string]string{
+		"Authorization": fmt.Sprintf("Bearer %s", accessToken.String()),
+	}
+
+	code, _, body, _ := request(t, s.URL(), http.MethodGet, "/api/me?full=true", requestHeader, nil)
+
+	expectedBody := fmt.Sprintf(`{"userInfo":{"id":%q,"email":"john.doe@example.com"}}`, s.UserID().String())
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, expectedBody, string(body))
+}
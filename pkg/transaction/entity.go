@@ -53,7 +53,9 @@ type Transaction struct {
 	SmartContactID uuid.UUID `json:"smartContactId,omitempty" csv:"smartContactId"` // Format: uuid.
 	// Format: uuid.
 	// Required.
-	LinkID       uuid.UUID `json:"linkId" csv:"linkId"`
-	TxnCondition string    `json:"txnCondition,omitempty" csv:"txnCondition"`
-	Confirmed    int64     `json:"confirmed" csv:"confirmed"` // Required.
+	LinkID             uuid.UUID `json:"linkId" csv:"linkId"`
+	TxnCondition       string    `json:"txnCondition,omitempty" csv:"txnCondition"`
+	Confirmed          int64     `json:"confirmed" csv:"confirmed"`                             // Required.
+	MandateID          string    `json:"mandateId,omitempty" csv:"mandateId"`                   // The mandate reference of a SEPA direct debit.
+	CreditorIdentifier string    `json:"creditorIdentifier,omitempty" csv:"creditorIdentifier"` // The creditor ID of a SEPA direct debit.
 }
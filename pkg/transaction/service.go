@@ -3,6 +3,8 @@ package transaction
 import (
 	"context"
 	"time"
+
+	"github.com/google/uuid"
 )
 
 // Finder is a service to find n26 transactions.
@@ -10,3 +12,17 @@ type Finder interface {
 	// FindAllTransactionsInRange finds all transactions in a time period.
 	FindAllTransactionsInRange(ctx context.Context, from time.Time, to time.Time) ([]Transaction, error)
 }
+
+// Getter is a service to get a n26 transaction.
+type Getter interface {
+	// GetTransaction gets a transaction by its ID.
+	GetTransaction(ctx context.Context, id uuid.UUID) (*Transaction, error)
+}
+
+// Updater is a service to update n26 transactions.
+type Updater interface {
+	// SetTransactionMemo sets the memo of a transaction.
+	SetTransactionMemo(ctx context.Context, id uuid.UUID, memo string) error
+	// SetTransactionTags sets the tags of a transaction.
+	SetTransactionTags(ctx context.Context, id uuid.UUID, tags []string) error
+}
@@ -0,0 +1,2 @@
+// Package user provides contracts for N26 User APIs.
+package user
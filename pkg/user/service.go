@@ -0,0 +1,9 @@
+package user
+
+import "context"
+
+// Service is a service to get the n26 user information.
+type Service interface {
+	// Me gets the profile of the current user.
+	Me(ctx context.Context) (*Profile, error)
+}
@@ -9,3 +9,8 @@ func StringPtr(str string) *string {
 func Int64Ptr(i int64) *int64 {
 	return &i
 }
+
+// BoolPtr returns the a pointer of bool.
+func BoolPtr(b bool) *bool {
+	return &b
+}
@@ -19,3 +19,10 @@ func TestInt64Ptr(t *testing.T) {
 
 	assert.Equal(t, &expected, Int64Ptr(i))
 }
+
+func TestBoolPtr(t *testing.T) {
+	b := true
+	expected := true
+
+	assert.Equal(t, &expected, BoolPtr(b))
+}
@@ -0,0 +1,116 @@
+package n26api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/space"
+)
+
+const errorInsufficientFunds = "insufficient_funds"
+
+var (
+	// ErrInvalidSpaceTransfer indicates that the transfer is rejected, for example, the amount is not positive or the
+	// spaces are the same.
+	ErrInvalidSpaceTransfer = errors.New("invalid space transfer")
+	// ErrInsufficientFunds indicates that the balance of the source space is lower than the amount.
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrSpaceLocked indicates that one of the spaces is locked and money can not be moved from or to it.
+	ErrSpaceLocked = errors.New("space is locked")
+	// ErrSpaceNotFound indicates that one of the spaces does not exist.
+	ErrSpaceNotFound = errors.New("space not found")
+	// ErrIdempotencyKeyConflict indicates that the idempotency key is already used for another transfer.
+	ErrIdempotencyKeyConflict = errors.New("idempotency key is used for another transfer")
+)
+
+var _ space.Service = (*Client)(nil)
+
+type idempotencyKeyCtxKey struct{}
+
+// ContextWithIdempotencyKey sets the idempotency key of a transfer. N26 processes the transfers with the same key only
+// once, and replies the retries with the result of the first one. If the key is not set, a new one is generated for
+// every transfer.
+func ContextWithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+func idempotencyKey(ctx context.Context) string {
+	if key, ok := ctx.Value(idempotencyKeyCtxKey{}).(string); ok && key != "" {
+		return key
+	}
+
+	return uuid.New().String()
+}
+
+// TransferBetweenSpaces moves money from a space to another, see ContextWithIdempotencyKey for retrying a transfer
+// safely.
+func (c *Client) TransferBetweenSpaces(ctx context.Context, fromSpaceID, toSpaceID uuid.UUID, amount float64) (*space.Transfer, error) {
+	if amount <= 0 || fromSpaceID == toSpaceID {
+		return nil, ctxd.WrapError(ctx, ErrInvalidSpaceTransfer, "could not transfer between spaces",
+			"from_space_id", fromSpaceID,
+			"to_space_id", toSpaceID,
+			"amount", amount,
+		)
+	}
+
+	key := idempotencyKey(ctx)
+
+	res, err := c.api.PostAPISpacesTransaction(ctx, api.PostAPISpacesTransactionRequest{
+		IdempotencyKey: key,
+		Body: &api.TransferRequest{
+			FromSpaceID: fromSpaceID.String(),
+			ToSpaceID:   toSpaceID.String(),
+			Amount:      amount,
+		},
+	})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not transfer between spaces", "idempotency_key", key)
+	}
+
+	if res.ValueBadRequest != nil {
+		reason := ErrInvalidSpaceTransfer
+
+		if res.ValueBadRequest.Error == errorInsufficientFunds {
+			reason = ErrInsufficientFunds
+		}
+
+		return nil, ctxd.WrapError(ctx, reason, "could not transfer between spaces",
+			"from_space_id", fromSpaceID,
+			"amount", amount,
+			"reason", res.ValueBadRequest.UserMessage.Detail,
+		)
+	}
+
+	if res.ValueNotFound != nil {
+		return nil, ctxd.WrapError(ctx, ErrSpaceNotFound, "could not transfer between spaces",
+			"from_space_id", fromSpaceID,
+			"to_space_id", toSpaceID,
+		)
+	}
+
+	if res.ValueLocked != nil {
+		return nil, ctxd.WrapError(ctx, ErrSpaceLocked, "could not transfer between spaces",
+			"from_space_id", fromSpaceID,
+			"to_space_id", toSpaceID,
+			"reason", res.ValueLocked.UserMessage.Detail,
+		)
+	}
+
+	if res.ValueConflict != nil {
+		return nil, ctxd.WrapError(ctx, ErrIdempotencyKeyConflict, "could not transfer between spaces", "idempotency_key", key)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not transfer between spaces: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not transfer between spaces: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
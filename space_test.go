@@ -0,0 +1,204 @@
+package n26api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestClient_TransferBetweenSpaces(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	main := uuid.New()
+	savings := uuid.New()
+	missing := uuid.New()
+
+	newSpaces := func() *testkit.Spaces {
+		return testkit.NewSpaces().
+			WithBalance(main, 100).
+			WithBalance(savings, 20)
+	}
+
+	testCases := []struct {
+		scenario        string
+		spaces          *testkit.Spaces
+		mockServer      func(spaces *testkit.Spaces) testkit.ServerMocker
+		from            uuid.UUID
+		to              uuid.UUID
+		amount          float64
+		expectedBalance [2]float64
+		expectedError   string
+		expectedErr     error
+	}{
+		{
+			scenario: "amount is not positive",
+			spaces:   newSpaces(),
+			mockServer: func(*testkit.Spaces) testkit.ServerMocker {
+				return testkit.MockEmptyServer()
+			},
+			from:            main,
+			to:              savings,
+			amount:          0,
+			expectedBalance: [2]float64{100, 20},
+			expectedError:   "could not transfer between spaces: invalid space transfer",
+			expectedErr:     n26api.ErrInvalidSpaceTransfer,
+		},
+		{
+			scenario: "same space",
+			spaces:   newSpaces(),
+			mockServer: func(*testkit.Spaces) testkit.ServerMocker {
+				return testkit.MockEmptyServer()
+			},
+			from:            main,
+			to:              main,
+			amount:          10,
+			expectedBalance: [2]float64{100, 20},
+			expectedError:   "could not transfer between spaces: invalid space transfer",
+			expectedErr:     n26api.ErrInvalidSpaceTransfer,
+		},
+		{
+			scenario: "server error",
+			spaces:   newSpaces(),
+			mockServer: func(*testkit.Spaces) testkit.ServerMocker {
+				return mockServer(deviceID, func(s *testkit.Server) {
+					s.ExpectPost("/api/spaces/transaction").
+						ReturnCode(http.StatusInternalServerError)
+				})
+			},
+			from:            main,
+			to:              savings,
+			amount:          10,
+			expectedBalance: [2]float64{100, 20},
+			expectedError:   "could not transfer between spaces: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario: "space not found",
+			spaces:   newSpaces(),
+			mockServer: func(spaces *testkit.Spaces) testkit.ServerMocker {
+				return mockServer(deviceID, testkit.WithTransferBetweenSpaces(spaces, "", main, missing, 10))
+			},
+			from:            main,
+			to:              missing,
+			amount:          10,
+			expectedBalance: [2]float64{100, 20},
+			expectedError:   "could not transfer between spaces: space not found",
+			expectedErr:     n26api.ErrSpaceNotFound,
+		},
+		{
+			scenario: "space is locked",
+			spaces:   newSpaces().WithLocked(savings),
+			mockServer: func(spaces *testkit.Spaces) testkit.ServerMocker {
+				return mockServer(deviceID, testkit.WithTransferBetweenSpaces(spaces, "", main, savings, 10))
+			},
+			from:            main,
+			to:              savings,
+			amount:          10,
+			expectedBalance: [2]float64{100, 20},
+			expectedError:   "could not transfer between spaces: space is locked",
+			expectedErr:     n26api.ErrSpaceLocked,
+		},
+		{
+			scenario: "insufficient funds",
+			spaces:   newSpaces(),
+			mockServer: func(spaces *testkit.Spaces) testkit.ServerMocker {
+				return mockServer(deviceID, testkit.WithTransferBetweenSpaces(spaces, "", savings, main, 20.01))
+			},
+			from:            savings,
+			to:              main,
+			amount:          20.01,
+			expectedBalance: [2]float64{100, 20},
+			expectedError:   "could not transfer between spaces: insufficient funds",
+			expectedErr:     n26api.ErrInsufficientFunds,
+		},
+		{
+			scenario: "success",
+			spaces:   newSpaces(),
+			mockServer: func(spaces *testkit.Spaces) testkit.ServerMocker {
+				return mockServer(deviceID, testkit.WithTransferBetweenSpaces(spaces, "", main, savings, 25.5))
+			},
+			from:            main,
+			to:              savings,
+			amount:          25.5,
+			expectedBalance: [2]float64{74.5, 45.5},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(tc.spaces)(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.TransferBetweenSpaces(context.Background(), tc.from, tc.to, tc.amount)
+
+			assert.Equal(t, tc.expectedBalance, [2]float64{tc.spaces.Balance(main), tc.spaces.Balance(savings)})
+
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tc.from, result.FromSpaceID)
+				assert.Equal(t, tc.to, result.ToSpaceID)
+				assert.Equal(t, tc.amount, result.Amount)
+
+				return
+			}
+
+			assert.Nil(t, result)
+			assert.EqualError(t, err, tc.expectedError)
+
+			if tc.expectedErr != nil {
+				assert.True(t, errors.Is(err, tc.expectedErr))
+			}
+		})
+	}
+}
+
+func TestClient_TransferBetweenSpaces_Idempotency(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	main := uuid.New()
+	savings := uuid.New()
+
+	spaces := testkit.NewSpaces().
+		WithBalance(main, 100).
+		WithBalance(savings, 0)
+
+	s := mockServer(deviceID,
+		testkit.WithTransferBetweenSpaces(spaces, "payday-2021-03", main, savings, 60),
+		testkit.WithTransferBetweenSpaces(spaces, "payday-2021-03", main, savings, 60),
+		testkit.WithTransferBetweenSpaces(spaces, "payday-2021-03", main, savings, 40),
+	)(t)
+	c := newTestClient(s, deviceID)
+
+	ctx := n26api.ContextWithIdempotencyKey(context.Background(), "payday-2021-03")
+
+	first, err := c.TransferBetweenSpaces(ctx, main, savings, 60)
+	require.NoError(t, err)
+
+	// The retry is not processed again, otherwise there are insufficient funds.
+	retry, err := c.TransferBetweenSpaces(ctx, main, savings, 60)
+	require.NoError(t, err)
+
+	assert.Equal(t, first, retry)
+	assert.Equal(t, 40.0, spaces.Balance(main))
+	assert.Equal(t, 60.0, spaces.Balance(savings))
+
+	// The key can not be reused for another transfer.
+	result, err := c.TransferBetweenSpaces(ctx, main, savings, 40)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "could not transfer between spaces: idempotency key is used for another transfer")
+	assert.True(t, errors.Is(err, n26api.ErrIdempotencyKeyConflict))
+	assert.Equal(t, 40.0, spaces.Balance(main))
+}
@@ -0,0 +1,56 @@
+package n26api
+
+import (
+	"context"
+	"time"
+
+	"github.com/bool64/ctxd"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/statistics"
+	"github.com/nhatthm/n26api/pkg/util"
+)
+
+var _ statistics.Service = (*Client)(nil)
+
+// CategoryStatistics gets income and expenses by category in a time period.
+func (c *Client) CategoryStatistics(ctx context.Context, from time.Time, to time.Time) (*statistics.Categories, error) {
+	res, err := c.api.GetAPISmrtStatisticsCategoriesFromTo(ctx, api.GetAPISmrtStatisticsCategoriesFromToRequest{
+		From: util.UnixTimestampMS(from),
+		To:   util.UnixTimestampMS(to),
+	})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not get category statistics", "from", from, "to", to)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not get category statistics: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not get category statistics: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
+
+// MonthlyStatistics gets income and expenses by month in a time period.
+func (c *Client) MonthlyStatistics(ctx context.Context, from time.Time, to time.Time) (*statistics.Months, error) {
+	res, err := c.api.GetAPISmrtStatisticsMonthsFromTo(ctx, api.GetAPISmrtStatisticsMonthsFromToRequest{
+		From: util.UnixTimestampMS(from),
+		To:   util.UnixTimestampMS(to),
+	})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not get monthly statistics", "from", from, "to", to)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not get monthly statistics: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not get monthly statistics: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
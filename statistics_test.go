@@ -0,0 +1,148 @@
+package n26api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/statistics"
+	"github.com/nhatthm/n26api/pkg/testkit"
+)
+
+func TestClient_CategoryStatistics(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	f := testkit.NewStatisticsFixture()
+
+	testCases := []struct {
+		scenario       string
+		mockServer     testkit.ServerMocker
+		expectedResult *statistics.Categories
+		expectedError  string
+	}{
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/smrt/statistics/categories/1609455600000/1617231599999").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not get category statistics: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:       "success",
+			mockServer:     mockServer(deviceID, testkit.WithCategoryStatistics(f.From, f.To, f.Categories)),
+			expectedResult: &f.Categories,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.CategoryStatistics(context.Background(), f.From, f.To)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_MonthlyStatistics(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	f := testkit.NewStatisticsFixture()
+
+	testCases := []struct {
+		scenario       string
+		mockServer     testkit.ServerMocker
+		expectedResult *statistics.Months
+		expectedError  string
+	}{
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/smrt/statistics/months/1609455600000/1617231599999").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not get monthly statistics: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:       "success",
+			mockServer:     mockServer(deviceID, testkit.WithMonthlyStatistics(f.From, f.To, f.Months)),
+			expectedResult: &f.Months,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := newTestClient(s, deviceID)
+
+			result, err := c.MonthlyStatistics(context.Background(), f.From, f.To)
+
+			assert.Equal(t, tc.expectedResult, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+// TestClient_Statistics_Consistency ensures the statistics computed from the transactions match the ones from N26.
+func TestClient_Statistics_Consistency(t *testing.T) {
+	t.Parallel()
+
+	const pageSize = 3
+
+	deviceID := uuid.New()
+	f := testkit.NewStatisticsFixture()
+
+	s := mockServer(deviceID, testkit.WithStatisticsFixture(f, pageSize))(t)
+	c := newTestClient(s, deviceID, n26api.WithTransactionsPageSize(pageSize))
+
+	ctx := context.Background()
+
+	categories, err := c.CategoryStatistics(ctx, f.From, f.To)
+	require.NoError(t, err)
+
+	months, err := c.MonthlyStatistics(ctx, f.From, f.To)
+	require.NoError(t, err)
+
+	transactions, err := c.FindAllTransactionsInRange(ctx, f.From, f.To)
+	require.NoError(t, err)
+
+	expectedCategories := statistics.AggregateByCategory(f.From, f.To, transactions)
+	expectedMonths := statistics.AggregateByMonth(f.From, f.To, transactions)
+
+	assert.Equal(t, expectedCategories.Income, categories.Income)
+	assert.Equal(t, expectedCategories.Expense, categories.Expense)
+	assert.Equal(t, expectedCategories.Total, categories.Total)
+	assert.ElementsMatch(t, expectedCategories.Items, categories.Items)
+
+	assert.Equal(t, expectedMonths.Income, months.Income)
+	assert.Equal(t, expectedMonths.Expense, months.Expense)
+	assert.Equal(t, expectedMonths.Total, months.Total)
+	assert.ElementsMatch(t, expectedMonths.Items, months.Items)
+}
@@ -11,9 +11,11 @@ import (
 	"github.com/bool64/ctxd"
 	"github.com/google/uuid"
 	"go.nhat.io/clock"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/nhatthm/n26api/internal/api"
 	"github.com/nhatthm/n26api/pkg/auth"
+	"github.com/nhatthm/n26api/pkg/metrics"
 	"github.com/nhatthm/n26api/pkg/util"
 )
 
@@ -28,72 +30,119 @@ var _ auth.TokenProvider = (*apiTokenProvider)(nil)
 
 var emptyToken = auth.OAuthToken{}
 
+// credentials are the username and the password for logging in, and the errors of the credentials providers that
+// could not provide them.
+type credentials struct {
+	username string
+	password string
+	err      error
+}
+
 type apiTokenProvider struct {
 	api         *api.Client
 	credentials CredentialsProvider
 	storage     auth.TokenStorage
 	clock       clock.Clock
+	transport   http.RoundTripper
+
+	tracerProvider trace.TracerProvider
+	tracer         trace.Tracer // tracer is nil if the tracing is disabled.
+	metrics        metrics.Metrics
+	logger         ctxd.Logger // logger is nil if the logging is disabled.
 
 	deviceID uuid.UUID
+	userID   uuid.UUID
 
-	mfaTimeout time.Duration
-	mfaWait    time.Duration
-	refreshTTL time.Duration
+	mfaTimeout  time.Duration
+	mfaWait     time.Duration
+	mfaProgress MFAProgressFunc
+	refreshTTL  time.Duration
+
+	mu     sync.Mutex
+	userMu sync.RWMutex
+}
 
-	mu sync.Mutex
+// debug logs a step of the authentication if the logging is enabled.
+func (p *apiTokenProvider) debug(ctx context.Context, msg string, keysAndValues ...interface{}) {
+	if p.logger != nil {
+		p.logger.Debug(ctx, msg, keysAndValues...)
+	}
 }
 
 func (p *apiTokenProvider) getToken(ctx context.Context, key string) (auth.OAuthToken, error) {
 	return p.storage.Get(ctx, key)
 }
 
-func (p *apiTokenProvider) setToken(ctx context.Context, key string, res api.TokenResponse, timestamp time.Time) (auth.OAuthToken, error) {
-	expiryDuration := time.Duration(res.ExpiresIn * int64(time.Second))
-
+// setToken persists the token of a response that is received at the given time. The expiry is the `exp` claim if the
+// access token is a JWT, otherwise it is derived from `expires_in`.
+func (p *apiTokenProvider) setToken(ctx context.Context, key, grantType string, res api.TokenResponse, receivedAt time.Time) (auth.OAuthToken, error) {
 	token := auth.OAuthToken{
 		AccessToken:      auth.Token(res.AccessToken),
 		RefreshToken:     auth.Token(res.RefreshToken),
-		ExpiresAt:        timestamp.Add(expiryDuration),
-		RefreshExpiresAt: timestamp.Add(p.refreshTTL),
+		ExpiresAt:        receivedAt.Add(time.Duration(res.ExpiresIn * int64(time.Second))),
+		RefreshExpiresAt: receivedAt.Add(p.refreshTTL),
 	}
 
-	if err := p.storage.Set(ctx, key, token); err != nil {
+	if claims, err := token.AccessToken.Claims(); err == nil && !claims.ExpiresAt.IsZero() {
+		token.ExpiresAt = claims.ExpiresAt
+	}
+
+	if err := p.storage.Set(auth.ContextWithGrantType(ctx, grantType), key, token); err != nil {
 		return auth.OAuthToken{}, err
 	}
 
 	return token, nil
 }
 
-func (p *apiTokenProvider) login(ctx context.Context) (string, error) {
-	password := p.credentials.Password()
-	if password == "" {
-		return "", ctxd.WrapError(ctx, ErrPasswordIsEmpty, "could not get token")
-	}
-
+// login logs in with the username and the password, and returns the MFA token, or the outcome of the failure for the
+// metrics.
+func (p *apiTokenProvider) login(ctx context.Context, username, password string) (string, string, error) {
 	res, err := p.api.PostOauthToken(ctx, api.PostOauthTokenRequest{
 		DeviceToken: p.deviceID.String(),
-		GrantType:   "password",
-		Username:    util.StringPtr(p.credentials.Username()),
-		Password:    util.StringPtr(p.credentials.Password()),
+		GrantType:   auth.GrantTypePassword,
+		Username:    util.StringPtr(username),
+		Password:    util.StringPtr(password),
 	})
 	if err != nil {
-		return "", ctxd.WrapError(ctx, err, "unexpected response")
+		return "", metrics.OutcomeError, ctxd.WrapError(ctx, err, "unexpected response")
 	}
 
 	statusCode := res.StatusCode
 
 	switch statusCode {
 	case http.StatusBadRequest:
-		return "", ctxd.NewError(ctx, "wrong credentials", "response", res)
+		return "", metrics.OutcomeWrongCredentials, ctxd.NewError(ctx, "wrong credentials", "response", redact(res))
 
 	case http.StatusForbidden:
-		return res.ValueForbidden.MfaToken, nil
+		p.setUserID(res.ValueForbidden.UserID)
+
+		return res.ValueForbidden.MfaToken, "", nil
 
 	case http.StatusTooManyRequests:
-		return "", ctxd.NewError(ctx, "too many login attempts", "response", res)
+		return "", metrics.OutcomeTooManyAttempts, ctxd.NewError(ctx, "too many login attempts", "response", redact(res))
+	}
+
+	return "", "", err
+}
+
+func (p *apiTokenProvider) setUserID(id string) {
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return
 	}
 
-	return "", err
+	p.userMu.Lock()
+	defer p.userMu.Unlock()
+
+	p.userID = userID
+}
+
+// UserID returns the ID of the user captured from the last login.
+func (p *apiTokenProvider) UserID() uuid.UUID {
+	p.userMu.RLock()
+	defer p.userMu.RUnlock()
+
+	return p.userID
 }
 
 func (p *apiTokenProvider) challenge(ctx context.Context, token string) error {
@@ -118,7 +167,7 @@ func (p *apiTokenProvider) challenge(ctx context.Context, token string) error {
 func (p *apiTokenProvider) confirmLogin(ctx context.Context, token string) (*api.TokenResponse, error) {
 	res, err := p.api.PostOauthToken(ctx, api.PostOauthTokenRequest{
 		DeviceToken: p.deviceID.String(),
-		GrantType:   "mfa_oob",
+		GrantType:   auth.GrantTypeMFAOOB,
 		MfaToken:    util.StringPtr(token),
 	})
 	if err != nil {
@@ -126,67 +175,112 @@ func (p *apiTokenProvider) confirmLogin(ctx context.Context, token string) (*api
 	}
 
 	if res.ValueOK == nil {
-		return nil, ctxd.NewError(ctx, "could not get access token", "response", res)
+		return nil, ctxd.NewError(ctx, "could not get access token", "response", redact(res))
 	}
 
 	return res.ValueOK, nil
 }
 
-func (p *apiTokenProvider) get(ctx context.Context, key string, timestamp time.Time) (auth.Token, error) {
-	mfaToken, err := p.login(ctx)
+func (p *apiTokenProvider) get(ctx context.Context, key string, c credentials) (_ auth.Token, err error) {
+	if c.password == "" {
+		return "", ctxd.WrapError(ctx, errors.Join(ErrPasswordIsEmpty, c.err), "could not get token")
+	}
+
+	ctx, span := startSpan(ctx, p.tracer, "n26api.login")
+	defer func() { endSpan(span, err) }()
+
+	outcome := metrics.OutcomeError
+	defer func() { p.metrics.ObserveLogin(outcome) }()
+
+	p.debug(ctx, "logging in", "username", c.username, "device_id", p.deviceID)
+
+	mfaToken, failure, err := p.login(ctx, c.username, c.password)
 	if err != nil {
+		outcome = failure
+
+		p.debug(ctx, "could not log in", "username", c.username, "outcome", outcome, "error", err)
+
 		return "", err
 	}
 
+	p.debug(ctx, "challenging mfa", "username", c.username, "mfa_token", mfaToken)
+
 	if err := p.challenge(ctx, mfaToken); err != nil {
+		p.debug(ctx, "could not challenge mfa", "username", c.username, "error", err)
+
 		return "", err
 	}
 
-	timeout, cancel := context.WithTimeout(ctx, p.mfaTimeout)
-	defer cancel()
+	token, outcome, err := p.waitForConfirmation(ctx, key, mfaToken)
+	if outcome == metrics.OutcomeConfirmed {
+		outcome = metrics.OutcomeSuccess
+	}
 
-	ticker := time.NewTicker(p.mfaWait)
+	p.debug(ctx, "logged in", "username", c.username, "outcome", outcome, "error", err)
 
-	for {
-		select {
-		case <-ticker.C:
-			res, _ := p.confirmLogin(timeout, mfaToken) // nolint:errcheck
+	return token, err
+}
 
-			if res != nil {
-				token, err := p.setToken(ctx, key, *res, timestamp)
-				if err != nil {
-					return "", ctxd.WrapError(ctx, err, "could not persist token to storage")
-				}
+// waitForConfirmation polls until the login is confirmed on the paired device, or the MFA timeout. It returns the
+// outcome of the wait for the metrics.
+func (p *apiTokenProvider) waitForConfirmation(ctx context.Context, key, mfaToken string) (auth.Token, string, error) {
+	var res *api.TokenResponse
 
-				return token.AccessToken, nil
-			}
+	outcome, err := p.waitForMFA(ctx, "login", func(ctx context.Context) (bool, error) {
+		var err error
 
-		case <-timeout.Done():
-			return "", ctxd.NewError(ctx, "could not confirm login", "reason", "timeout")
+		// The login is not confirmed as long as there is no token.
+		if res, err = p.confirmLogin(ctx, mfaToken); err != nil {
+			return false, fmt.Errorf("%w: %w", errNotConfirmed, err)
 		}
+
+		return true, nil
+	})
+	if err != nil {
+		return "", outcome, err
 	}
+
+	token, err := p.setToken(ctx, key, auth.GrantTypeMFAOOB, *res, p.clock.Now())
+	if err != nil {
+		return "", metrics.OutcomeError, ctxd.WrapError(ctx, err, "could not persist token to storage")
+	}
+
+	return token.AccessToken, outcome, nil
 }
 
-func (p *apiTokenProvider) refresh(ctx context.Context, key string, refreshToken auth.Token, timestamp time.Time) (auth.Token, error) {
+func (p *apiTokenProvider) refresh(ctx context.Context, key string, c credentials, refreshToken auth.Token) (auth.Token, error) {
+	p.debug(ctx, "refreshing token", "key", key, "refresh_token", refreshToken)
+
 	res, err := p.api.PostOauthToken(ctx, api.PostOauthTokenRequest{
 		DeviceToken:  p.deviceID.String(),
-		GrantType:    "refresh_token",
+		GrantType:    auth.GrantTypeRefreshToken,
 		RefreshToken: util.StringPtr(string(refreshToken)),
 	})
 	if err != nil {
+		p.metrics.ObserveRefresh(metrics.OutcomeError)
+		p.debug(ctx, "could not refresh token", "key", key, "error", err)
+
 		return "", ctxd.WrapError(ctx, err, "failed to refresh token")
 	}
 
 	if res.ValueOK != nil {
-		token, err := p.setToken(ctx, key, *res.ValueOK, timestamp)
+		token, err := p.setToken(ctx, key, auth.GrantTypeRefreshToken, *res.ValueOK, p.clock.Now())
 		if err != nil {
+			p.metrics.ObserveRefresh(metrics.OutcomeError)
+
 			return "", ctxd.WrapError(ctx, err, "could not persist token to storage")
 		}
 
+		p.metrics.ObserveRefresh(metrics.OutcomeSuccess)
+		p.debug(ctx, "refreshed token", "key", key, "expires_at", token.ExpiresAt)
+
 		return token.AccessToken, nil
 	}
 
-	return p.get(ctx, key, timestamp)
+	p.metrics.ObserveRefresh(metrics.OutcomeRejected)
+	p.debug(ctx, "refresh token is rejected, logging in again", "key", key, "status_code", res.StatusCode)
+
+	return p.get(ctx, key, c)
 }
 
 func (p *apiTokenProvider) WithBaseURL(baseURL string) *apiTokenProvider {
@@ -217,7 +311,61 @@ func (p *apiTokenProvider) WithStorage(storage auth.TokenStorage) *apiTokenProvi
 func (p *apiTokenProvider) WithTransport(transport http.RoundTripper) *apiTokenProvider {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.api.SetTransport(BasicAuthRoundTripper(auth.BasicAuthUsername, auth.BasicAuthPassword, transport))
+	p.transport = transport
+	p.setTransport()
+
+	return p
+}
+
+func (p *apiTokenProvider) WithTracerProvider(provider trace.TracerProvider) *apiTokenProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.tracerProvider = provider
+	p.tracer = nil
+
+	if provider != nil {
+		p.tracer = newTracer(provider)
+	}
+
+	p.setTransport()
+
+	return p
+}
+
+func (p *apiTokenProvider) WithMetrics(m metrics.Metrics) *apiTokenProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.metrics = m
+	p.setTransport()
+
+	return p
+}
+
+// setTransport sets the transport of the api client, with the basic authorization, the metrics, and the tracing and the
+// logging if enabled.
+func (p *apiTokenProvider) setTransport() {
+	transport := p.transport
+
+	if p.logger != nil {
+		transport = loggingRoundTripper(p.logger, transport)
+	}
+
+	transport = BasicAuthRoundTripper(auth.BasicAuthUsername, auth.BasicAuthPassword,
+		metricsRoundTripper(p.metrics, transport),
+	)
+
+	if p.tracerProvider != nil {
+		transport = tracingRoundTripper(p.tracer, transport)
+	}
+
+	p.api.SetTransport(transport)
+}
+
+func (p *apiTokenProvider) WithLogger(logger ctxd.Logger) *apiTokenProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.logger = newRedactingLogger(logger)
+	p.setTransport()
 
 	return p
 }
@@ -238,6 +386,14 @@ func (p *apiTokenProvider) WithMFAWait(waitTime time.Duration) *apiTokenProvider
 	return p
 }
 
+func (p *apiTokenProvider) WithMFAProgress(progress MFAProgressFunc) *apiTokenProvider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.mfaProgress = progress
+
+	return p
+}
+
 func (p *apiTokenProvider) WithRefreshTTL(ttl time.Duration) *apiTokenProvider {
 	p.mu.Lock()
 	defer p.mu.Unlock()
@@ -258,11 +414,12 @@ func (p *apiTokenProvider) Token(ctx context.Context) (auth.Token, error) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	username := p.credentials.Username()
+	username, password, err := UpgradeCredentialsProvider(p.credentials).Credentials(ctx)
 	if username == "" {
-		return "", ctxd.WrapError(ctx, ErrUsernameIsEmpty, "could not get token")
+		return "", ctxd.WrapError(ctx, errors.Join(ErrUsernameIsEmpty, err), "could not get token")
 	}
 
+	c := credentials{username: username, password: password, err: err}
 	key := fmt.Sprintf("%s:%s", username, p.deviceID.String())
 	now := p.clock.Now()
 
@@ -272,18 +429,20 @@ func (p *apiTokenProvider) Token(ctx context.Context) (auth.Token, error) {
 	}
 
 	if token == emptyToken {
-		return p.get(ctx, key, now)
+		return p.get(ctx, key, c)
 	}
 
 	if !token.IsExpired(now) {
+		p.debug(ctx, "using token from storage", "key", key, "expires_at", token.ExpiresAt)
+
 		return token.AccessToken, nil
 	}
 
 	if token.IsRefreshable(now) {
-		return p.refresh(ctx, key, token.RefreshToken, now)
+		return p.refresh(ctx, key, c, token.RefreshToken)
 	}
 
-	return p.get(ctx, key, now)
+	return p.get(ctx, key, c)
 }
 
 func newAPITokenProvider(
@@ -293,6 +452,7 @@ func newAPITokenProvider(
 	c := api.NewClient()
 	c.BaseURL = BaseURL
 	c.Timeout = time.Minute
+	c.InstrumentCtxFunc = instrumentCtx
 	c.SetTransport(BasicAuthRoundTripper(
 		auth.BasicAuthUsername, auth.BasicAuthPassword,
 		http.DefaultTransport,
@@ -303,6 +463,8 @@ func newAPITokenProvider(
 		credentials: credentials,
 		storage:     NewInMemoryTokenStorage(),
 		clock:       clock.New(),
+		transport:   http.DefaultTransport,
+		metrics:     metrics.Discard(),
 
 		deviceID: deviceID,
 
@@ -2,6 +2,7 @@ package n26api
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"testing"
@@ -10,8 +11,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	clock "go.nhat.io/clock/mock"
 
+	"github.com/nhatthm/n26api/internal/api"
 	"github.com/nhatthm/n26api/pkg/auth"
 	"github.com/nhatthm/n26api/pkg/testkit"
 	authMock "github.com/nhatthm/n26api/pkg/testkit/auth"
@@ -123,7 +126,7 @@ func TestApiTokenProvider_GetToken(t *testing.T) {
 					s.On("Get", context.Background(), storageKey).
 						Return(auth.OAuthToken{}, nil)
 
-					s.On("Set", context.Background(), storageKey, mock.Anything).
+					s.On("Set", auth.ContextWithGrantType(context.Background(), auth.GrantTypeMFAOOB), storageKey, mock.Anything).
 						Return(errors.New("set token error"))
 				})(t)
 
@@ -161,6 +164,7 @@ func TestApiTokenProvider_GetToken(t *testing.T) {
 
 			if tc.expectedError == "" {
 				assert.Equal(t, s.AccessToken(), token)
+				assert.Equal(t, s.UserID(), p.UserID())
 				assert.NoError(t, err)
 			} else {
 				assert.Empty(t, token)
@@ -190,6 +194,13 @@ func TestApiTokenProvider_GetToken_MissingCredentials(t *testing.T) {
 			credentials:   Credentials("username", ""),
 			expectedError: "could not get token: missing password",
 		},
+		{
+			scenario: "credentials provider error",
+			credentials: downgradeCredentialsProvider(CredentialsFunc(func(context.Context) (string, string, error) {
+				return "username", "", errors.New("vault is locked")
+			})),
+			expectedError: "could not get token: missing password\nvault is locked",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -221,8 +232,9 @@ func TestApiTokenProvider_GetTokenFromCache(t *testing.T) {
 	)(t)
 
 	c := clock.Mock(func(c *clock.Clock) {
-		c.On("Now").Return(timestamp).Once()
-		// 2nd is after 4 minutes to check TTL.
+		// Before the login, at the start and the end of the MFA wait, and when the token is received.
+		c.On("Now").Return(timestamp).Times(4)
+		// 5th is after 4 minutes to check TTL.
 		c.On("Now").Return(timestamp.Add(4 * time.Minute)).Once()
 	})(t)
 
@@ -259,10 +271,11 @@ func TestApiTokenProvider_RefreshToken(t *testing.T) {
 	storageKey := fmt.Sprintf("%s:%s", username, deviceID.String())
 
 	mockClock := clock.Mock(func(c *clock.Clock) {
-		// 1st step: Get token.
-		c.On("Now").Return(timestamp).Once()
-		// 2nd step: Refresh token.
-		c.On("Now").Return(timestamp.Add(refreshTTL - time.Minute)).Once()
+		// 1st step: Get token, before the login, at the start and the end of the MFA wait, and when the token is
+		// received.
+		c.On("Now").Return(timestamp).Times(4)
+		// 2nd step: Refresh token, before the refresh and when the token is received if any.
+		c.On("Now").Return(timestamp.Add(refreshTTL - time.Minute))
 	})
 
 	testCases := []struct {
@@ -306,7 +319,7 @@ func TestApiTokenProvider_RefreshToken(t *testing.T) {
 							nil,
 						)
 
-					s.On("Set", context.Background(), storageKey, mock.Anything).
+					s.On("Set", auth.ContextWithGrantType(context.Background(), auth.GrantTypeRefreshToken), storageKey, mock.Anything).
 						Return(errors.New("set token error"))
 				})(t)
 
@@ -378,10 +391,11 @@ func TestApiTokenProvider_TokenExpired(t *testing.T) {
 	)(t)
 
 	c := clock.Mock(func(c *clock.Clock) {
-		// 1st step: Get token.
-		c.On("Now").Return(timestamp).Once()
-		// 2nd step: Refresh token.
-		c.On("Now").Return(timestamp.Add(refreshTTL + time.Minute)).Once()
+		// 1st step: Get token, before the login, at the start and the end of the MFA wait, and when the token is
+		// received.
+		c.On("Now").Return(timestamp).Times(4)
+		// 2nd step: Get a new token, the same.
+		c.On("Now").Return(timestamp.Add(refreshTTL + time.Minute)).Times(4)
 	})(t)
 
 	p := newAPITokenProvider(cred, deviceID).
@@ -406,3 +420,67 @@ func TestApiTokenProvider_TokenExpired(t *testing.T) {
 	assert.NotEmpty(t, string(token2))
 	assert.NoError(t, err)
 }
+
+func TestApiTokenProvider_SetToken(t *testing.T) {
+	t.Parallel()
+
+	receivedAt := time.Date(2020, 1, 2, 3, 0, 0, 0, time.UTC)
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+
+	testCases := []struct {
+		scenario          string
+		accessToken       string
+		expectedExpiresAt time.Time
+	}{
+		{
+			scenario:          "access token is not a jwt",
+			accessToken:       uuid.New().String(),
+			expectedExpiresAt: receivedAt.Add(889 * time.Second),
+		},
+		{
+			scenario:          "access token has no exp claim",
+			accessToken:       header + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"john.doe"}`)) + ".signature",
+			expectedExpiresAt: receivedAt.Add(889 * time.Second),
+		},
+		{
+			scenario:          "access token has an exp claim",
+			accessToken:       header + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"exp":1577934245}`)) + ".signature",
+			expectedExpiresAt: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			storage := NewInMemoryTokenStorage()
+			p := newAPITokenProvider(Credentials("john.doe", "123456"), uuid.New()).
+				WithStorage(storage).
+				WithRefreshTTL(time.Hour)
+
+			res := api.TokenResponse{
+				AccessToken:  tc.accessToken,
+				RefreshToken: "refresh",
+				ExpiresIn:    889,
+			}
+
+			token, err := p.setToken(context.Background(), "key", auth.GrantTypeMFAOOB, res, receivedAt)
+			require.NoError(t, err)
+
+			expected := auth.OAuthToken{
+				AccessToken:      auth.Token(tc.accessToken),
+				RefreshToken:     "refresh",
+				ExpiresAt:        tc.expectedExpiresAt,
+				RefreshExpiresAt: receivedAt.Add(time.Hour),
+			}
+
+			assert.Equal(t, expected, token)
+
+			stored, err := storage.Get(context.Background(), "key")
+			require.NoError(t, err)
+
+			assert.Equal(t, expected, stored)
+		})
+	}
+}
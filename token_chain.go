@@ -2,20 +2,66 @@ package n26api
 
 import (
 	"context"
+	"errors"
 
 	"github.com/nhatthm/n26api/pkg/auth"
 )
 
 var _ auth.TokenProvider = (*chainTokenProvider)(nil)
 
-type chainTokenProvider []auth.TokenProvider
+// ChainPolicy decides whether a chain of providers tries the next provider when a provider fails. It returns true to
+// skip the failed provider, or false to stop.
+type ChainPolicy func(err error) bool
 
-// Token provides the first non-empty token from the chain.
+// ChainFailFast is a ChainPolicy that stops at the first error. It is the default policy.
+func ChainFailFast(error) bool {
+	return false
+}
+
+// ChainSkipOnError is a ChainPolicy that skips the providers that fail.
+func ChainSkipOnError(error) bool {
+	return true
+}
+
+// ChainSkipOn returns a ChainPolicy that skips the providers that fail with one of the given errors, see errors.Is,
+// and stops at the other errors.
+func ChainSkipOn(targets ...error) ChainPolicy {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+type chainTokenProvider struct {
+	providers []auth.TokenProvider
+	policy    ChainPolicy
+}
+
+// Token provides the first non-empty token from the chain. When a provider fails, the policy decides whether the next
+// provider is tried. If no token is found, the errors of all the providers that were tried are returned.
 func (chain *chainTokenProvider) Token(ctx context.Context) (auth.Token, error) {
-	for _, p := range *chain {
+	policy := chain.policy
+	if policy == nil {
+		policy = ChainFailFast
+	}
+
+	var errs []error
+
+	for _, p := range chain.providers {
 		token, err := p.Token(ctx)
 		if err != nil {
-			return "", err
+			errs = append(errs, err)
+
+			if !policy(err) {
+				break
+			}
+
+			continue
 		}
 
 		if token != "" {
@@ -23,32 +69,47 @@ func (chain *chainTokenProvider) Token(ctx context.Context) (auth.Token, error)
 		}
 	}
 
-	return "", nil
+	return "", errors.Join(errs...)
 }
 
 // append appends a new provider to the chain.
 func (chain *chainTokenProvider) append(provider auth.TokenProvider) {
-	*chain = append(*chain, provider)
+	chain.providers = append(chain.providers, provider)
 }
 
 // prepend prepends a new provider to the chain.
 func (chain *chainTokenProvider) prepend(provider auth.TokenProvider) {
-	*chain = append(*chain, provider)
-	copy((*chain)[1:], *chain)
-	(*chain)[0] = provider
+	chain.providers = append(chain.providers, provider)
+	copy(chain.providers[1:], chain.providers)
+	chain.providers[0] = provider
 }
 
 // newChainTokenProvider initiates a chain of auth.TokenProvider.
 func newChainTokenProvider() *chainTokenProvider {
-	chain := make(chainTokenProvider, 0)
-
-	return &chain
+	return &chainTokenProvider{
+		providers: make([]auth.TokenProvider, 0),
+	}
 }
 
 // chainTokenProviders chains a list of auth.TokenProvider.
 func chainTokenProviders(providers ...auth.TokenProvider) *chainTokenProvider {
 	chain := newChainTokenProvider()
-	*chain = providers
+	chain.providers = providers
+
+	return chain
+}
+
+// ChainTokenProviders chains a list of auth.TokenProvider, the first non-empty token is provided. The chain stops at
+// the first error, see ChainTokenProvidersWithPolicy.
+func ChainTokenProviders(providers ...auth.TokenProvider) auth.TokenProvider {
+	return chainTokenProviders(providers...)
+}
+
+// ChainTokenProvidersWithPolicy chains a list of auth.TokenProvider, the policy decides whether the next provider is
+// tried when a provider fails.
+func ChainTokenProvidersWithPolicy(policy ChainPolicy, providers ...auth.TokenProvider) auth.TokenProvider {
+	chain := chainTokenProviders(providers...)
+	chain.policy = policy
 
 	return chain
 }
@@ -3,6 +3,7 @@ package n26api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -73,3 +74,110 @@ func provideTokenProviders(token auth.Token, err error) []authMock.TokenProvider
 		}),
 	}
 }
+
+func TestChainTokenProvider_Policy(t *testing.T) {
+	t.Parallel()
+
+	errLocked := errors.New("vault is locked")
+	errBroken := errors.New("provider is broken")
+
+	testCases := []struct {
+		scenario      string
+		policy        ChainPolicy
+		mockProviders []authMock.TokenProviderMocker
+		expectedToken auth.Token
+		expectedError string
+	}{
+		{
+			scenario: "fail fast",
+			policy:   ChainFailFast,
+			mockProviders: []authMock.TokenProviderMocker{
+				mockTokenProvider("", errLocked),
+				authMock.NoMockTokenProvider,
+			},
+			expectedError: "vault is locked",
+		},
+		{
+			scenario: "skip on error and found",
+			policy:   ChainSkipOnError,
+			mockProviders: []authMock.TokenProviderMocker{
+				mockTokenProvider("", errLocked),
+				mockTokenProvider("token", nil),
+				authMock.NoMockTokenProvider,
+			},
+			expectedToken: "token",
+		},
+		{
+			scenario: "skip on error and not found",
+			policy:   ChainSkipOnError,
+			mockProviders: []authMock.TokenProviderMocker{
+				mockTokenProvider("", errLocked),
+				mockTokenProvider("", nil),
+				mockTokenProvider("", errBroken),
+			},
+			expectedError: "vault is locked\nprovider is broken",
+		},
+		{
+			scenario: "skip on specific error",
+			policy:   ChainSkipOn(errLocked),
+			mockProviders: []authMock.TokenProviderMocker{
+				mockTokenProvider("", fmt.Errorf("could not get token: %w", errLocked)),
+				mockTokenProvider("token", nil),
+			},
+			expectedToken: "token",
+		},
+		{
+			scenario: "stop on other errors",
+			policy:   ChainSkipOn(errLocked),
+			mockProviders: []authMock.TokenProviderMocker{
+				mockTokenProvider("", errLocked),
+				mockTokenProvider("", errBroken),
+				authMock.NoMockTokenProvider,
+			},
+			expectedError: "vault is locked\nprovider is broken",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			providers := make([]auth.TokenProvider, 0, len(tc.mockProviders))
+
+			for _, mockProvider := range tc.mockProviders {
+				providers = append(providers, mockProvider(t))
+			}
+
+			token, err := ChainTokenProvidersWithPolicy(tc.policy, providers...).Token(context.Background())
+
+			assert.Equal(t, tc.expectedToken, token)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestChainTokenProviders(t *testing.T) {
+	t.Parallel()
+
+	p := ChainTokenProviders(
+		mockTokenProvider("", errors.New("failed to get token"))(t),
+		authMock.NoMockTokenProvider(t),
+	)
+
+	token, err := p.Token(context.Background())
+
+	assert.Empty(t, token)
+	assert.EqualError(t, err, "failed to get token")
+}
+
+func mockTokenProvider(token auth.Token, err error) authMock.TokenProviderMocker {
+	return authMock.MockTokenProvider(func(p *authMock.TokenProvider) {
+		p.On("Token", context.Background()).Return(token, err)
+	})
+}
@@ -3,22 +3,38 @@ package n26api
 import (
 	"context"
 	"errors"
+	"sort"
+	"sync"
+
+	"github.com/bool64/ctxd"
 
 	"github.com/nhatthm/n26api/pkg/auth"
 )
 
-// ErrTokenKeyEmpty indicates that the key of token is empty and we can not persist that.
-var ErrTokenKeyEmpty = errors.New("token key is empty")
+var (
+	// ErrTokenKeyEmpty indicates that the key of token is empty and we can not persist that.
+	ErrTokenKeyEmpty = errors.New("token key is empty")
+	// ErrTokenKeysUnknown indicates that the keys of the tokens are not given and the storage can not list them.
+	ErrTokenKeysUnknown = errors.New("token keys are unknown")
+)
 
-var _ auth.TokenStorage = (*InMemoryTokenStorage)(nil)
+var (
+	_ auth.TokenStorage   = (*InMemoryTokenStorage)(nil)
+	_ auth.TokenKeyLister = (*InMemoryTokenStorage)(nil)
+)
 
 // InMemoryTokenStorage persists auth.OAuthToken into its memory.
 type InMemoryTokenStorage struct {
 	storage map[string]auth.OAuthToken
+
+	mu sync.RWMutex
 }
 
 // Get gets OAuthToken from memory.
 func (s *InMemoryTokenStorage) Get(_ context.Context, key string) (auth.OAuthToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	return s.storage[key], nil
 }
 
@@ -28,14 +44,68 @@ func (s *InMemoryTokenStorage) Set(_ context.Context, key string, token auth.OAu
 		return ErrTokenKeyEmpty
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	s.storage[key] = token
 
 	return nil
 }
 
+// Keys lists the keys of the tokens in memory.
+func (s *InMemoryTokenStorage) Keys(context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.storage))
+
+	for key := range s.storage {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
 // NewInMemoryTokenStorage initiates a new InMemoryTokenStorage.
 func NewInMemoryTokenStorage() *InMemoryTokenStorage {
 	return &InMemoryTokenStorage{
 		storage: make(map[string]auth.OAuthToken),
 	}
 }
+
+// MigrateTokenStorage copies the tokens from a storage to another, for example from an InMemoryTokenStorage to a
+// shared storage. If the keys are not given, the source storage has to list them, see auth.TokenKeyLister. The empty
+// tokens are skipped.
+func MigrateTokenStorage(ctx context.Context, src, dst auth.TokenStorage, keys ...string) error {
+	if len(keys) == 0 {
+		lister, ok := src.(auth.TokenKeyLister)
+		if !ok {
+			return ctxd.WrapError(ctx, ErrTokenKeysUnknown, "could not migrate token storage")
+		}
+
+		var err error
+
+		if keys, err = lister.Keys(ctx); err != nil {
+			return ctxd.WrapError(ctx, err, "could not migrate token storage")
+		}
+	}
+
+	for _, key := range keys {
+		token, err := src.Get(ctx, key)
+		if err != nil {
+			return ctxd.WrapError(ctx, err, "could not migrate token storage", "key", key)
+		}
+
+		if token == (auth.OAuthToken{}) {
+			continue
+		}
+
+		if err := dst.Set(ctx, key, token); err != nil {
+			return ctxd.WrapError(ctx, err, "could not migrate token storage", "key", key)
+		}
+	}
+
+	return nil
+}
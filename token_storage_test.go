@@ -2,6 +2,7 @@ package n26api_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/nhatthm/n26api"
 	"github.com/nhatthm/n26api/pkg/auth"
+	authMock "github.com/nhatthm/n26api/pkg/testkit/auth"
 )
 
 func TestInMemoryTokenStorage_GetMissingKey(t *testing.T) {
@@ -77,3 +79,115 @@ func TestInMemoryTokenStorage_SetError(t *testing.T) {
 
 	assert.Equal(t, n26api.ErrTokenKeyEmpty, err)
 }
+
+func TestInMemoryTokenStorage_Keys(t *testing.T) {
+	t.Parallel()
+
+	s := n26api.NewInMemoryTokenStorage()
+
+	require.NoError(t, s.Set(context.Background(), "john.doe", auth.OAuthToken{AccessToken: "john"}))
+	require.NoError(t, s.Set(context.Background(), "jane.doe", auth.OAuthToken{AccessToken: "jane"}))
+
+	keys, err := s.Keys(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"jane.doe", "john.doe"}, keys)
+}
+
+func TestMigrateTokenStorage(t *testing.T) {
+	t.Parallel()
+
+	timestamp := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	john := auth.OAuthToken{AccessToken: "john", ExpiresAt: timestamp}
+	jane := auth.OAuthToken{AccessToken: "jane", ExpiresAt: timestamp}
+
+	src := n26api.NewInMemoryTokenStorage()
+
+	require.NoError(t, src.Set(context.Background(), "john.doe", john))
+	require.NoError(t, src.Set(context.Background(), "jane.doe", jane))
+
+	dst := n26api.NewInMemoryTokenStorage()
+
+	err := n26api.MigrateTokenStorage(context.Background(), src, dst)
+	require.NoError(t, err)
+
+	keys, err := dst.Keys(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"jane.doe", "john.doe"}, keys)
+
+	token, err := dst.Get(context.Background(), "john.doe")
+	require.NoError(t, err)
+
+	assert.Equal(t, john, token)
+}
+
+func TestMigrateTokenStorage_Keys(t *testing.T) {
+	t.Parallel()
+
+	john := auth.OAuthToken{AccessToken: "john"}
+
+	src := authMock.MockTokenStorage(func(s *authMock.TokenStorage) {
+		s.On("Get", context.Background(), "john.doe").Return(john, nil)
+		s.On("Get", context.Background(), "jane.doe").Return(auth.OAuthToken{}, nil)
+	})(t)
+
+	dst := authMock.MockTokenStorage(func(s *authMock.TokenStorage) {
+		s.On("Set", context.Background(), "john.doe", john).Return(nil)
+	})(t)
+
+	err := n26api.MigrateTokenStorage(context.Background(), src, dst, "john.doe", "jane.doe")
+
+	assert.NoError(t, err)
+}
+
+func TestMigrateTokenStorage_Error(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		scenario      string
+		mockSrc       authMock.TokenStorageMocker
+		mockDst       authMock.TokenStorageMocker
+		keys          []string
+		expectedError string
+	}{
+		{
+			scenario:      "keys are unknown",
+			mockSrc:       authMock.NoMockTokenStorage,
+			mockDst:       authMock.NoMockTokenStorage,
+			expectedError: "could not migrate token storage: token keys are unknown",
+		},
+		{
+			scenario: "could not get token",
+			mockSrc: authMock.MockTokenStorage(func(s *authMock.TokenStorage) {
+				s.On("Get", context.Background(), "john.doe").Return(auth.OAuthToken{}, errors.New("get error"))
+			}),
+			mockDst:       authMock.NoMockTokenStorage,
+			keys:          []string{"john.doe"},
+			expectedError: "could not migrate token storage: get error",
+		},
+		{
+			scenario: "could not set token",
+			mockSrc: authMock.MockTokenStorage(func(s *authMock.TokenStorage) {
+				s.On("Get", context.Background(), "john.doe").Return(auth.OAuthToken{AccessToken: "john"}, nil)
+			}),
+			mockDst: authMock.MockTokenStorage(func(s *authMock.TokenStorage) {
+				s.On("Set", context.Background(), "john.doe", auth.OAuthToken{AccessToken: "john"}).
+					Return(errors.New("set error"))
+			}),
+			keys:          []string{"john.doe"},
+			expectedError: "could not migrate token storage: set error",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			err := n26api.MigrateTokenStorage(context.Background(), tc.mockSrc(t), tc.mockDst(t), tc.keys...)
+
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package n26api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/nhatthm/n26api/internal/api"
+)
+
+// tracerName is the name of the instrumentation library.
+const tracerName = "github.com/nhatthm/n26api"
+
+const (
+	attrMethod     = attribute.Key("http.request.method")
+	attrStatusCode = attribute.Key("http.response.status_code")
+	attrHost       = attribute.Key("server.address")
+	attrPattern    = attribute.Key("n26api.pattern")
+	attrGrantType  = attribute.Key("n26api.grant_type")
+	attrPage       = attribute.Key("n26api.page")
+	attrMFAPoll    = attribute.Key("n26api.mfa.poll")
+	attrConfirmed  = attribute.Key("n26api.mfa.confirmed")
+)
+
+type (
+	ctxOperation struct{}
+	ctxPage      struct{}
+)
+
+// operation is the operation of the generated api.Client that sends a request.
+type operation struct {
+	method  string
+	pattern string
+	attrs   []attribute.KeyValue
+}
+
+// WithTracerProvider traces the requests to N26 with OpenTelemetry. There is a span for each operation, for example
+// `GET /api/smrt/transactions`, with the status code, the page number and the grant type, and there are spans for the
+// login and each poll of the MFA confirmation. The credentials and the tokens are never recorded.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *Client) {
+		c.config.tracerProvider = provider
+	}
+}
+
+// newTracer creates the tracer of the library.
+func newTracer(provider trace.TracerProvider) trace.Tracer {
+	return provider.Tracer(tracerName)
+}
+
+// startSpan starts a span if the tracing is enabled, otherwise the context is not changed and the span does nothing.
+func startSpan(ctx context.Context, tracer trace.Tracer, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	if tracer == nil {
+		return ctx, trace.SpanFromContext(context.Background())
+	}
+
+	return tracer.Start(ctx, name, opts...)
+}
+
+// instrumentCtx adds the operation info to the context, it is the api.Client.InstrumentCtxFunc.
+func instrumentCtx(ctx context.Context, method, pattern string, reqStruct interface{}) context.Context {
+	op := operation{
+		method:  method,
+		pattern: pattern,
+	}
+
+	if req, ok := reqStruct.(*api.PostOauthTokenRequest); ok {
+		op.attrs = append(op.attrs, attrGrantType.String(req.GrantType))
+	}
+
+	return context.WithValue(ctx, ctxOperation{}, op)
+}
+
+// contextWithPage adds the page number of a paginated operation to the context.
+func contextWithPage(ctx context.Context, page int) context.Context {
+	return context.WithValue(ctx, ctxPage{}, page)
+}
+
+// tracingRoundTripper starts a span for each request, the span is named by the operation if any, otherwise by the
+// method only, the path may contain ids and is not used in the span name.
+func tracingRoundTripper(tracer trace.Tracer, tripper http.RoundTripper) RoundTripperFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		ctx := r.Context()
+		name := "HTTP " + r.Method
+		attrs := []attribute.KeyValue{
+			attrMethod.String(r.Method),
+			attrHost.String(r.URL.Hostname()),
+		}
+
+		if op, ok := ctx.Value(ctxOperation{}).(operation); ok {
+			name = fmt.Sprintf("%s %s", op.method, op.pattern)
+			attrs = append(attrs, attrPattern.String(op.pattern))
+			attrs = append(attrs, op.attrs...)
+		}
+
+		if page, ok := ctx.Value(ctxPage{}).(int); ok {
+			attrs = append(attrs, attrPage.Int(page))
+		}
+
+		ctx, span := tracer.Start(ctx, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(attrs...),
+		)
+
+		resp, err := tripper.RoundTrip(r.WithContext(ctx))
+		if err != nil {
+			endSpan(span, err)
+
+			return nil, err
+		}
+
+		span.SetAttributes(attrStatusCode.Int(resp.StatusCode))
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			span.SetStatus(codes.Error, resp.Status)
+		}
+
+		span.End()
+
+		return resp, nil
+	}
+}
+
+// endSpan ends a span, and records the error if any.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,202 @@
+package n26api_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/pkg/testkit"
+	"github.com/nhatthm/n26api/pkg/transaction"
+)
+
+func TestWithTracerProvider(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	from := time.Now()
+	to := from.Add(time.Hour)
+	transactions := []transaction.Transaction{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	s := testkit.MockEmptyServer(
+		testkit.WithAuthPasswordLoginSuccess(n26Username, n26Password, deviceID),
+		testkit.WithAuthMFAChallengeSuccess(),
+		testkit.WithAuthConfirmLoginFailureInvalidToken(1),
+		testkit.WithAuthConfirmLoginSuccess(),
+		testkit.WithFindAllTransactionsInRange(from, to, 1, transactions),
+	)(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c := n26api.NewClient(
+		n26api.WithBaseURL(s.URL()),
+		n26api.WithDeviceID(deviceID),
+		n26api.WithCredentials(n26Username, n26Password),
+		n26api.WithMFAWait(5*time.Millisecond),
+		n26api.WithMFATimeout(time.Second),
+		n26api.WithTransactionsPageSize(1),
+		n26api.WithTracerProvider(provider),
+	)
+
+	result, err := c.FindAllTransactionsInRange(context.Background(), from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, transactions, result)
+
+	spans := exporter.GetSpans()
+	byID := make(map[string]tracetest.SpanStub, len(spans))
+
+	for _, span := range spans {
+		byID[span.SpanContext.SpanID().String()] = span
+	}
+
+	parentName := func(span tracetest.SpanStub) string {
+		return byID[span.Parent.SpanID().String()].Name
+	}
+
+	type spanInfo struct {
+		name   string
+		parent string
+		attrs  map[attribute.Key]attribute.Value
+	}
+
+	actual := make([]spanInfo, 0, len(spans))
+
+	for _, span := range spans {
+		attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+
+		for _, attr := range span.Attributes {
+			attrs[attr.Key] = attr.Value
+
+			// The credentials and the tokens are never recorded.
+			for _, secret := range []string{
+				n26Password,
+				string(s.AccessToken()),
+				string(s.RefreshToken()),
+				s.MFAToken().String(),
+			} {
+				assert.False(t, strings.Contains(attr.Value.Emit(), secret), "attribute %s has a secret", attr.Key)
+			}
+		}
+
+		actual = append(actual, spanInfo{name: span.Name, parent: parentName(span), attrs: attrs})
+	}
+
+	// The spans are exported when they end.
+	expected := []struct {
+		name   string
+		parent string
+		attrs  map[attribute.Key]attribute.Value
+	}{
+		{
+			name:   "POST /oauth/token",
+			parent: "n26api.login",
+			attrs: map[attribute.Key]attribute.Value{
+				"n26api.grant_type":         attribute.StringValue("password"),
+				"http.response.status_code": attribute.IntValue(http.StatusForbidden),
+			},
+		},
+		{name: "POST /api/mfa/challenge", parent: "n26api.login"},
+		{
+			name:   "POST /oauth/token",
+			parent: "n26api.mfa.poll",
+			attrs: map[attribute.Key]attribute.Value{
+				"n26api.grant_type":         attribute.StringValue("mfa_oob"),
+				"http.response.status_code": attribute.IntValue(http.StatusUnauthorized),
+			},
+		},
+		{
+			name:   "n26api.mfa.poll",
+			parent: "n26api.mfa.wait",
+			attrs: map[attribute.Key]attribute.Value{
+				"n26api.mfa.poll":      attribute.IntValue(1),
+				"n26api.mfa.confirmed": attribute.BoolValue(false),
+			},
+		},
+		{
+			name:   "POST /oauth/token",
+			parent: "n26api.mfa.poll",
+			attrs: map[attribute.Key]attribute.Value{
+				"n26api.grant_type":         attribute.StringValue("mfa_oob"),
+				"http.response.status_code": attribute.IntValue(http.StatusOK),
+			},
+		},
+		{
+			name:   "n26api.mfa.poll",
+			parent: "n26api.mfa.wait",
+			attrs: map[attribute.Key]attribute.Value{
+				"n26api.mfa.poll":      attribute.IntValue(2),
+				"n26api.mfa.confirmed": attribute.BoolValue(true),
+			},
+		},
+		{name: "n26api.mfa.wait", parent: "n26api.login"},
+		{name: "n26api.login", parent: "GET /api/smrt/transactions"},
+		{
+			name: "GET /api/smrt/transactions",
+			attrs: map[attribute.Key]attribute.Value{
+				"n26api.page":               attribute.IntValue(1),
+				"http.request.method":       attribute.StringValue(http.MethodGet),
+				"n26api.pattern":            attribute.StringValue("/api/smrt/transactions"),
+				"http.response.status_code": attribute.IntValue(http.StatusOK),
+			},
+		},
+		{
+			name:  "GET /api/smrt/transactions",
+			attrs: map[attribute.Key]attribute.Value{"n26api.page": attribute.IntValue(2)},
+		},
+		{
+			name:  "GET /api/smrt/transactions",
+			attrs: map[attribute.Key]attribute.Value{"n26api.page": attribute.IntValue(3)},
+		},
+	}
+
+	require.Len(t, actual, len(expected))
+
+	for i, e := range expected {
+		assert.Equal(t, e.name, actual[i].name, "span #%d", i)
+		assert.Equal(t, e.parent, actual[i].parent, "parent of span #%d %s", i, e.name)
+
+		for key, value := range e.attrs {
+			assert.Equal(t, value, actual[i].attrs[key], "attribute %s of span #%d %s", key, i, e.name)
+		}
+	}
+}
+
+func TestWithTracerProvider_Attachment(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	transactionID := uuid.New()
+	attachmentID := uuid.New()
+
+	s := mockServer(deviceID, testkit.WithDownloadAttachment(transactionID, attachmentID, "application/pdf", pdfContent))(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	c := newTestClient(s, deviceID, n26api.WithTracerProvider(provider))
+
+	err := c.DownloadAttachment(context.Background(), transactionID, attachmentID, io.Discard)
+	require.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	last := spans[len(spans)-1]
+
+	// The ids are not in the span name.
+	assert.Equal(t, "GET /api/smrt/transactions/{id}/attachments/{attachmentId}", last.Name)
+
+	for _, span := range spans {
+		assert.NotContains(t, span.Name, transactionID.String())
+	}
+}
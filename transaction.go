@@ -2,16 +2,25 @@ package n26api
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/bool64/ctxd"
+	"github.com/google/uuid"
 
 	"github.com/nhatthm/n26api/internal/api"
 	"github.com/nhatthm/n26api/pkg/transaction"
 	"github.com/nhatthm/n26api/pkg/util"
 )
 
-var _ transaction.Finder = (*Client)(nil)
+// ErrTransactionNotFound indicates that the transaction does not exist.
+var ErrTransactionNotFound = errors.New("transaction not found")
+
+var (
+	_ transaction.Finder  = (*Client)(nil)
+	_ transaction.Getter  = (*Client)(nil)
+	_ transaction.Updater = (*Client)(nil)
+)
 
 // WithTransactionsPageSize sets page size limit for finding transactions.
 func WithTransactionsPageSize(limit int64) Option {
@@ -50,7 +59,7 @@ func (c *Client) FindAllTransactionsInRange(ctx context.Context, from time.Time,
 			break
 		}
 
-		trans, err := c.findTransactions(ctx, api.GetAPISmrtTransactionsRequest{
+		trans, err := c.findTransactions(contextWithPage(ctx, page), api.GetAPISmrtTransactionsRequest{
 			From:   util.Int64Ptr(util.UnixTimestampMS(from)),
 			To:     util.Int64Ptr(util.UnixTimestampMS(to)),
 			Limit:  util.Int64Ptr(limit),
@@ -74,5 +83,77 @@ func (c *Client) FindAllTransactionsInRange(ctx context.Context, from time.Time,
 		}
 	}
 
+	c.config.metrics.ObservePages(operationFindAllTransactionsInRange, page-1)
+
 	return result, nil
 }
+
+// GetTransaction gets a transaction by its ID.
+func (c *Client) GetTransaction(ctx context.Context, id uuid.UUID) (*transaction.Transaction, error) {
+	res, err := c.api.GetAPISmrtTransactionsID(ctx, api.GetAPISmrtTransactionsIDRequest{
+		ID: id.String(),
+	})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not get transaction", "id", id)
+	}
+
+	if res.ValueNotFound != nil {
+		return nil, ctxd.WrapError(ctx, ErrTransactionNotFound, "could not get transaction", "id", id)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not get transaction: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not get transaction: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
+
+// SetTransactionMemo sets the memo of a transaction.
+func (c *Client) SetTransactionMemo(ctx context.Context, id uuid.UUID, memo string) error {
+	res, err := c.api.PutAPISmrtTransactionsIDMemo(ctx, api.PutAPISmrtTransactionsIDMemoRequest{
+		ID:   id.String(),
+		Body: &api.TransactionMemoRequest{Memo: memo},
+	})
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not set transaction memo", "id", id)
+	}
+
+	if res.ValueNotFound != nil {
+		return ctxd.WrapError(ctx, ErrTransactionNotFound, "could not set transaction memo", "id", id)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return ctxd.NewError(ctx, "could not set transaction memo: invalid token", "response", res)
+	}
+
+	return nil
+}
+
+// SetTransactionTags sets the tags of a transaction.
+func (c *Client) SetTransactionTags(ctx context.Context, id uuid.UUID, tags []string) error {
+	if tags == nil {
+		tags = []string{}
+	}
+
+	res, err := c.api.PutAPISmrtTransactionsIDTags(ctx, api.PutAPISmrtTransactionsIDTagsRequest{
+		ID:   id.String(),
+		Body: &api.TransactionTagsRequest{Tags: tags},
+	})
+	if err != nil {
+		return ctxd.WrapError(ctx, err, "could not set transaction tags", "id", id)
+	}
+
+	if res.ValueNotFound != nil {
+		return ctxd.WrapError(ctx, ErrTransactionNotFound, "could not set transaction tags", "id", id)
+	}
+
+	if res.ValueUnauthorized != nil {
+		return ctxd.NewError(ctx, "could not set transaction tags: invalid token", "response", res)
+	}
+
+	return nil
+}
@@ -123,3 +123,179 @@ func TestClient_FindAllTransactionsInRange(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_GetTransaction(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario            string
+		mockServer          testkit.ServerMocker
+		expectedTransaction *transaction.Transaction
+		expectedError       string
+	}{
+		{
+			scenario:      "not found",
+			mockServer:    mockServer(deviceID, testkit.WithGetTransactionFailureNotFound(id)),
+			expectedError: "could not get transaction: transaction not found",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/smrt/transactions/" + id.String()).
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not get transaction: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:            "success",
+			mockServer:          mockServer(deviceID, testkit.WithGetTransaction(transaction.Transaction{ID: id, Amount: -3.5})),
+			expectedTransaction: &transaction.Transaction{ID: id, Amount: -3.5},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := n26api.NewClient(
+				n26api.WithBaseURL(s.URL()),
+				n26api.WithDeviceID(deviceID),
+				n26api.WithCredentials(n26Username, n26Password),
+				n26api.WithMFAWait(5*time.Millisecond),
+				n26api.WithMFATimeout(time.Second),
+			)
+
+			result, err := c.GetTransaction(context.Background(), id)
+
+			assert.Equal(t, tc.expectedTransaction, result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_SetTransactionMemo(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockServer    testkit.ServerMocker
+		expectedError string
+	}{
+		{
+			scenario: "not found",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPut("/api/smrt/transactions/" + id.String() + "/memo").
+					ReturnCode(http.StatusNotFound).
+					ReturnJSON(api.NotFoundError{Status: http.StatusNotFound})
+			}),
+			expectedError: "could not set transaction memo: transaction not found",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPut("/api/smrt/transactions/" + id.String() + "/memo").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedError: "could not set transaction memo: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:   "success",
+			mockServer: mockServer(deviceID, testkit.WithSetTransactionMemoSuccess(id, "team lunch")),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := n26api.NewClient(
+				n26api.WithBaseURL(s.URL()),
+				n26api.WithDeviceID(deviceID),
+				n26api.WithCredentials(n26Username, n26Password),
+				n26api.WithMFAWait(5*time.Millisecond),
+				n26api.WithMFATimeout(time.Second),
+			)
+
+			err := c.SetTransactionMemo(context.Background(), id, "team lunch")
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_SetTransactionTags(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	id := uuid.New()
+
+	testCases := []struct {
+		scenario      string
+		mockServer    testkit.ServerMocker
+		tags          []string
+		expectedError string
+	}{
+		{
+			scenario: "not found",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectPut("/api/smrt/transactions/" + id.String() + "/tags").
+					ReturnCode(http.StatusNotFound).
+					ReturnJSON(api.NotFoundError{Status: http.StatusNotFound})
+			}),
+			tags:          []string{"business"},
+			expectedError: "could not set transaction tags: transaction not found",
+		},
+		{
+			scenario:   "clear tags",
+			mockServer: mockServer(deviceID, testkit.WithSetTransactionTagsSuccess(id, []string{})),
+		},
+		{
+			scenario:   "success",
+			mockServer: mockServer(deviceID, testkit.WithSetTransactionTagsSuccess(id, []string{"business", "travel"})),
+			tags:       []string{"business", "travel"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := n26api.NewClient(
+				n26api.WithBaseURL(s.URL()),
+				n26api.WithDeviceID(deviceID),
+				n26api.WithCredentials(n26Username, n26Password),
+				n26api.WithMFAWait(5*time.Millisecond),
+				n26api.WithMFATimeout(time.Second),
+			)
+
+			err := c.SetTransactionTags(context.Background(), id, tc.tags)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net/http"
 
+	"golang.org/x/time/rate"
+
 	"github.com/nhatthm/n26api/pkg/auth"
 	"github.com/nhatthm/n26api/pkg/util"
 )
@@ -51,3 +53,14 @@ func TokenRoundTripper(p auth.TokenProvider, tripper http.RoundTripper) RoundTri
 		return tripper.RoundTrip(r)
 	}
 }
+
+// RateLimitRoundTripper waits for the rate limiter before sending the given request.
+func RateLimitRoundTripper(limiter *rate.Limiter, tripper http.RoundTripper) RoundTripperFunc {
+	return func(r *http.Request) (*http.Response, error) {
+		if err := limiter.Wait(r.Context()); err != nil {
+			return nil, err
+		}
+
+		return tripper.RoundTrip(r)
+	}
+}
@@ -0,0 +1,33 @@
+package n26api
+
+import (
+	"context"
+
+	"github.com/bool64/ctxd"
+
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/user"
+	"github.com/nhatthm/n26api/pkg/util"
+)
+
+var _ user.Service = (*Client)(nil)
+
+// Me gets the profile of the current user, including the account and the statuses.
+func (c *Client) Me(ctx context.Context) (*user.Profile, error) {
+	res, err := c.api.GetAPIMe(ctx, api.GetAPIMeRequest{
+		Full: util.BoolPtr(true),
+	})
+	if err != nil {
+		return nil, ctxd.WrapError(ctx, err, "could not get user profile")
+	}
+
+	if res.ValueUnauthorized != nil {
+		return nil, ctxd.NewError(ctx, "could not get user profile: invalid token", "response", res)
+	}
+
+	if res.ValueOK == nil {
+		return nil, ctxd.NewError(ctx, "could not get user profile: unexpected response", "response", res)
+	}
+
+	return res.ValueOK, nil
+}
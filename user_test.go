@@ -0,0 +1,129 @@
+package n26api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/nhatthm/n26api"
+	"github.com/nhatthm/n26api/internal/api"
+	"github.com/nhatthm/n26api/pkg/testkit"
+	"github.com/nhatthm/n26api/pkg/user"
+)
+
+func TestClient_Me(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+	profile := user.Profile{
+		Info: user.Info{
+			Email:       "john.doe@example.com",
+			FirstName:   "John",
+			LastName:    "Doe",
+			Nationality: "DEU",
+		},
+		Account: &user.Account{ID: uuid.New(), Status: "OPEN_PRIMARY_ACCOUNT"},
+		Status:  &user.Status{KycStatus: "COMPLETED"},
+	}
+
+	testCases := []struct {
+		scenario        string
+		mockServer      testkit.ServerMocker
+		expectedProfile func(s *testkit.Server) *user.Profile
+		expectedError   string
+	}{
+		{
+			scenario: "invalid token",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/me?full=true").
+					ReturnCode(http.StatusUnauthorized).
+					ReturnJSON(api.InvalidTokenError{
+						Status: http.StatusUnauthorized,
+						Detail: "Invalid token",
+						Type:   "error",
+						UserMessage: api.UserMessage{
+							Title:  "Login attempt expired",
+							Detail: "That took too long, please try again.",
+						},
+						Error:            "invalid_token",
+						ErrorDescription: "Invalid token",
+					})
+			}),
+			expectedProfile: func(*testkit.Server) *user.Profile { return nil },
+			expectedError:   "could not get user profile: invalid token",
+		},
+		{
+			scenario: "server error",
+			mockServer: mockServer(deviceID, func(s *testkit.Server) {
+				s.ExpectGet("/api/me?full=true").
+					ReturnCode(http.StatusInternalServerError)
+			}),
+			expectedProfile: func(*testkit.Server) *user.Profile { return nil },
+			expectedError:   "could not get user profile: unexpected response status: 500 Internal Server Error",
+		},
+		{
+			scenario:   "success",
+			mockServer: mockServer(deviceID, testkit.WithMe(profile)),
+			expectedProfile: func(s *testkit.Server) *user.Profile {
+				expected := profile
+				expected.Info.ID = s.UserID()
+
+				return &expected
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.scenario, func(t *testing.T) {
+			t.Parallel()
+
+			s := tc.mockServer(t)
+			c := n26api.NewClient(
+				n26api.WithBaseURL(s.URL()),
+				n26api.WithDeviceID(deviceID),
+				n26api.WithCredentials(n26Username, n26Password),
+				n26api.WithMFAWait(5*time.Millisecond),
+				n26api.WithMFATimeout(time.Second),
+			)
+
+			result, err := c.Me(context.Background())
+
+			assert.Equal(t, tc.expectedProfile(s), result)
+
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestClient_UserID(t *testing.T) {
+	t.Parallel()
+
+	deviceID := uuid.New()
+
+	s := mockServer(deviceID, testkit.WithMe(user.Profile{}))(t)
+	c := n26api.NewClient(
+		n26api.WithBaseURL(s.URL()),
+		n26api.WithDeviceID(deviceID),
+		n26api.WithCredentials(n26Username, n26Password),
+		n26api.WithMFAWait(5*time.Millisecond),
+		n26api.WithMFATimeout(time.Second),
+	)
+
+	// No login yet.
+	assert.Equal(t, uuid.UUID{}, c.UserID())
+
+	profile, err := c.Me(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, s.UserID(), c.UserID())
+	assert.Equal(t, c.UserID(), profile.Info.ID)
+}